@@ -21,20 +21,6 @@ const reportBaseName = "ctm_report"
 // To be replaced by Make (using LDFLAGS) setting the latest git commit ID
 var ctmVersion = "1.0.6"
 
-// Supported test result formats
-// xunit-xml format = https://github.com/windyroad/JUnit-Schema/blob/master/JUnit.xsd
-var supportedReporttypes = []string{"xunit-xml"}
-
-// Checks if a test report type (like maven-surefire, etc.) is already supported
-func reportTypeSupported(reportType string) bool {
-	for _, current := range supportedReporttypes {
-		if current == reportType {
-			return true
-		}
-	}
-	return false
-}
-
 func setupLogging(cfg utils.Config) {
 
 	// Setup logging
@@ -88,7 +74,11 @@ func createTraces(testSuite []testreport.TestSuite, backlogItems []mapping.TestB
 		for _, ts := range testSuite { // Checking in each test suite...
 			for _, tc := range ts.TestCase { // ...to find the test case
 				if sourceCodeTest.Matches(tc) {
-					tt = projectmanagement.TraceTest{SourceFile: sourceCodeTest.Test.FileURL, ReportFile: tc.ReportFileName, ClassName: tc.ClassName, MethodName: tc.MethodName, TestResult: tc.Result}
+					tt = projectmanagement.TraceTest{SourceFile: sourceCodeTest.Test.FileURL, ReportFile: tc.ReportFileName, ClassName: tc.ClassName, MethodName: tc.MethodName, Variant: tc.Variant, Environment: tc.Environment, TestResult: tc.Result,
+						Message: tc.Message, StackTrace: tc.StackTrace, Duration: tc.Duration, Stdout: tc.Stdout, Stderr: tc.Stderr}
+					for _, attempt := range tc.Attempts {
+						tt.Attempts = append(tt.Attempts, projectmanagement.TestAttempt{ReportFile: attempt.ReportFileName, TestResult: attempt.Result, Timestamp: attempt.Timestamp, Message: attempt.Message})
+					}
 					traces = addTraceTest(traces, &sourceCodeTest.BacklogItem, tt)
 				}
 			}
@@ -216,8 +206,11 @@ func main() {
 			case "gaugespec":
 				p = mapping.GaugeSpecParser{}
 				break
+			case "go":
+				p = mapping.GoParser{}
+				break
 			default:
-				glog.Fatal("Sourcecode language for parsing needs to be 'python', 'java', 'javascript' or 'gaugespec'")
+				glog.Fatal("Sourcecode language for parsing needs to be 'python', 'java', 'javascript', 'gaugespec' or 'go'")
 			}
 
 			biMapping = append(biMapping, p.Parse(cfg, sc)...)
@@ -227,9 +220,8 @@ func main() {
 	// Parse the test report to get test results
 	var testSuite = []testreport.TestSuite{}
 	for _, tr := range cfg.TestReport {
-		if reportTypeSupported(tr.Type) {
-			trXU := testreport.XUTestReport{}
-			suites := trXU.Parse(tr.Local)
+		if testReport, supported := testreport.GetTestReport(tr.Type); supported {
+			suites := testReport.Parse(tr.Local)
 			// Ensure we don't collect doublicates
 			for _, s := range suites {
 				found := false
@@ -237,8 +229,12 @@ func main() {
 					glog.Info("Empty Test Suite found")
 					continue
 				}
+				for _, tc := range s.TestCase { // The same tests might be run in multiple environments (e.g. JDKs, browsers or OSes)
+					tc.Environment = tr.Label
+				}
 				for _, cts := range testSuite {
-					if (cts.Name == s.Name) && (len(cts.TestCase) == len(s.TestCase)) && (s.TestCase[0].ReportFileName == cts.TestCase[0].ReportFileName) {
+					if (cts.Name == s.Name) && (len(cts.TestCase) == len(s.TestCase)) && (s.TestCase[0].ReportFileName == cts.TestCase[0].ReportFileName) &&
+						(s.TestCase[0].Environment == cts.TestCase[0].Environment) {
 						found = true
 						break
 					}
@@ -248,10 +244,18 @@ func main() {
 				}
 			}
 		} else {
-			glog.Error("Unsupported test report format. Supported formats are: ", supportedReporttypes)
+			glog.Error("Unsupported test report format. Supported formats are: ", testreport.SupportedTestReportTypes())
 		}
 	}
 
+	// The same test might have been run multiple times (e.g. in sharded and retried test jobs). Merge those runs into one
+	// result per test. Mixed outcomes mean the test is flaky (this is detected while merging)
+	testSuite = testreport.AggregateTestCases(testSuite, cfg.TestResults.MergeStrategy)
+
+	// Test reports might reference backlog items on their own (e.g. Allure links, Robot Framework tags or CTRF extra). Add those to the mapping
+	trm := mapping.TestReportMapping{RequirementProperties: cfg.Mapping.RequirementProperties, TestNameExtractors: cfg.Mapping.TestNameExtractors}
+	biMapping = mapping.MergeTestBacklog(biMapping, trm.Parse(testSuite))
+
 	// Map backlog items (from sourcecode) to test results
 	traces := createTraces(testSuite, biMapping)
 
@@ -318,6 +322,10 @@ func main() {
 	}
 	utils.TimeTrack(reportingStartTime, "Create HTML and JSON reports")
 
-	glog.Info("Number of NOT successful tested requirements: ", len(traces)-projectmanagement.GetNumberOfSuccessfulTestedTraces(traces))
+	glog.Info("Number of NOT successful tested requirements: ", len(traces)-projectmanagement.GetNumberOfSuccessfulTestedTraces(traces, cfg))
+	glog.Info("Number of failed requirements: ", projectmanagement.GetNumberOfTracesWithResult(traces, testreport.FAILURE, cfg),
+		", with errors: ", projectmanagement.GetNumberOfTracesWithResult(traces, testreport.ERROR, cfg),
+		", skipped: ", projectmanagement.GetNumberOfTracesWithResult(traces, testreport.SKIPPED, cfg),
+		", flaky: ", projectmanagement.GetNumberOfTracesWithResult(traces, testreport.FLAKY, cfg))
 
 }
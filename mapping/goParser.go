@@ -0,0 +1,283 @@
+package mapping
+
+import (
+	"bufio"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/SAP/quality-continuous-traceability-monitor/utils"
+	"github.com/golang/glog"
+)
+
+// GoParser implements the mapping.Parser interface for Go sourcecode
+type GoParser struct {
+}
+
+// Parse Go sourcecode (test files) to seek for traceability comments of test functions and their subtests
+func (gp GoParser) Parse(cfg utils.Config, sc utils.Sourcecode) []TestBacklog {
+
+	var scName string
+	if sc.Git.Organization != "" {
+		scName = sc.Git.Organization + "/" + sc.Git.Repository
+	} else {
+		scName = sc.Local
+	}
+
+	defer utils.TimeTrack(time.Now(), "Parse Go sourcecode ("+scName+")")
+
+	var tb = []TestBacklog{}
+	var importPaths = make(map[string]string) // Import path per directory
+
+	filepath.Walk(sc.Local, func(path string, fi os.FileInfo, err error) error {
+
+		if fi.IsDir() {
+			// Like the go tool, we ignore vendored packages, test data and hidden directories
+			if path != sc.Local && (fi.Name() == "vendor" || fi.Name() == "testdata" || strings.HasPrefix(fi.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if strings.HasSuffix(path, "_test.go") {
+
+			file, err := os.Open(path)
+			if err != nil {
+				panic(err)
+			}
+			defer file.Close()
+
+			dir := filepath.Dir(path)
+			if _, found := importPaths[dir]; !found {
+				importPaths[dir] = getGoImportPath(sc.Local, dir)
+			}
+
+			tb = append(tb, parseGo(file, cfg, sc, file, importPaths[dir])...)
+
+		}
+
+		return nil
+
+	})
+
+	return tb
+
+}
+
+// Names the tests the way go test does: The import path of the package is the class, the test function (e.g. TestX)
+// or subtest (e.g. TestX/sub) the method. Traceability comments are taken from the doc comment of the test function
+// and from the comment right above a t.Run call. Only subtests with a static name (string literal) are supported
+func parseGo(coding io.Reader, cfg utils.Config, sc utils.Sourcecode, file *os.File, importPath string) []TestBacklog {
+
+	var tb = []TestBacklog{}
+
+	src, err := ioutil.ReadAll(coding)
+	if err != nil {
+		glog.Error("Unable to read Go sourcecode file ", file.Name(), ": ", err)
+		return tb
+	}
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file.Name(), src, parser.ParseComments)
+	if err != nil {
+		glog.Error("Unable to parse Go sourcecode file ", file.Name(), ": ", err)
+		return tb
+	}
+
+	testingPkg := getTestingImportName(f)
+	if testingPkg == "" { // Not a test file at all
+		return tb
+	}
+
+	// Comments which end right above a line, e.g. the comment above a t.Run call
+	var commentAbove = make(map[int]*ast.CommentGroup)
+	for _, cg := range f.Comments {
+		commentAbove[fset.Position(cg.End()).Line+1] = cg
+	}
+
+	fileURL := getSourcecodeURL(cfg, sc, file)
+	addTest := func(method string, cg *ast.CommentGroup) {
+		if cg == nil {
+			return
+		}
+		var bli []BacklogItem
+		for _, marker := range reTraceMarker.FindAllString(cg.Text(), -1) {
+			bli = appendMissingBacklogItems(bli, GetBacklogItem(marker))
+		}
+		if bli != nil {
+			tb = append(tb, TestBacklog{Test: Test{FileURL: fileURL, ClassName: importPath, Method: method}, BacklogItem: bli})
+		}
+	}
+
+	// Subtests might have subtests on their own
+	var inspectSubtests func(body *ast.BlockStmt, t string, parentName string)
+	inspectSubtests = func(body *ast.BlockStmt, t string, parentName string) {
+		ast.Inspect(body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) != 2 {
+				return true
+			}
+			selector, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || selector.Sel.Name != "Run" || !isIdent(selector.X, t) {
+				return true
+			}
+			name, ok := call.Args[0].(*ast.BasicLit)
+			if !ok || name.Kind != token.STRING {
+				return true
+			}
+			subtestName, err := strconv.Unquote(name.Value)
+			if err != nil {
+				return true
+			}
+			subtestName = parentName + "/" + rewriteGoSubtestName(subtestName)
+			addTest(subtestName, commentAbove[fset.Position(call.Pos()).Line])
+
+			if subtest, ok := call.Args[1].(*ast.FuncLit); ok {
+				if subtestT := getTestingTParam(subtest.Type, testingPkg); subtestT != "" {
+					inspectSubtests(subtest.Body, subtestT, subtestName)
+				}
+			}
+			return false // The subtest body has been inspected already
+		})
+	}
+
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || fn.Body == nil || !isGoTestName(fn.Name.Name) {
+			continue
+		}
+		t := getTestingTParam(fn.Type, testingPkg)
+		if t == "" {
+			continue
+		}
+
+		addTest(fn.Name.Name, fn.Doc)
+		inspectSubtests(fn.Body, t, fn.Name.Name)
+	}
+
+	return tb
+
+}
+
+// Name of the testing package within the file (usually testing, but might be imported with another name)
+func getTestingImportName(f *ast.File) string {
+	for _, imp := range f.Imports {
+		if imp.Path.Value != `"testing"` {
+			continue
+		}
+		if imp.Name != nil {
+			return imp.Name.Name
+		}
+		return "testing"
+	}
+	return ""
+}
+
+// Name of the *testing.T parameter, if the function has exactly one (e.g. func TestX(t *testing.T))
+func getTestingTParam(fnType *ast.FuncType, testingPkg string) string {
+	if fnType.Params == nil || len(fnType.Params.List) != 1 || len(fnType.Params.List[0].Names) > 1 {
+		return ""
+	}
+	param := fnType.Params.List[0]
+	star, ok := param.Type.(*ast.StarExpr)
+	if !ok {
+		return ""
+	}
+	selector, ok := star.X.(*ast.SelectorExpr)
+	if !ok || selector.Sel.Name != "T" || !isIdent(selector.X, testingPkg) {
+		return ""
+	}
+	if len(param.Names) == 0 || param.Names[0].Name == "_" {
+		return "" // Unnamed, so no subtests can be run
+	}
+	return param.Names[0].Name
+}
+
+func isIdent(expr ast.Expr, name string) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == name
+}
+
+// Test functions are named TestXxx, where Xxx does not start with a lowercase letter (see go help testfunc)
+func isGoTestName(name string) bool {
+	if !strings.HasPrefix(name, "Test") {
+		return false
+	}
+	if len(name) == len("Test") {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(name[len("Test"):])
+	return !unicode.IsLower(r)
+}
+
+// go test rewrites subtest names: spaces become underscores and non printable characters are escaped
+func rewriteGoSubtestName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case unicode.IsSpace(r):
+			b.WriteRune('_')
+		case !strconv.IsPrint(r):
+			s := strconv.QuoteRune(r)
+			b.WriteString(s[1 : len(s)-1])
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Get the import path of the package in dir. It's taken from the module path (of the closest go.mod file within the
+// sourcecode root). Without go.mod file, the path relative to the sourcecode root is used
+func getGoImportPath(root string, dir string) string {
+	root = filepath.Clean(root)
+	for current := filepath.Clean(dir); ; current = filepath.Dir(current) {
+		if modulePath := getGoModulePath(filepath.Join(current, "go.mod")); modulePath != "" {
+			rel, err := filepath.Rel(current, dir)
+			if err != nil || rel == "." {
+				return modulePath
+			}
+			return path.Join(modulePath, filepath.ToSlash(rel))
+		}
+		if current == root || filepath.Dir(current) == current {
+			break
+		}
+	}
+
+	rel, err := filepath.Rel(root, dir)
+	if err != nil || rel == "." {
+		return filepath.Base(root)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// Module path of a go.mod file. An empty string is returned if there's no (valid) go.mod file
+func getGoModulePath(goModFilePath string) string {
+	goMod, err := os.Open(goModFilePath)
+	if err != nil {
+		return ""
+	}
+	defer goMod.Close()
+
+	scanner := bufio.NewScanner(goMod)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "module") {
+			modulePath := strings.TrimSpace(strings.TrimPrefix(line, "module"))
+			if i := strings.Index(modulePath, "//"); i != -1 {
+				modulePath = strings.TrimSpace(modulePath[:i])
+			}
+			return strings.Trim(modulePath, "\"`")
+		}
+	}
+	return ""
+}
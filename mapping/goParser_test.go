@@ -0,0 +1,122 @@
+package mapping
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/SAP/quality-continuous-traceability-monitor/utils"
+)
+
+// TestBacklog mappings (correct)
+var testGoCode = []testMapping{
+	{input: `package pkg
+
+	import "testing"
+
+	// Trace(Jira:MYPROJECT-1)
+	func TestLogin(t *testing.T) {
+		t.Run("valid user", func(t *testing.T) {
+		})
+
+		// Trace(GitHub:myorg/myRepo#1)
+		t.Run("invalid user", func(st *testing.T) {
+			// Trace(Jira:MYPROJECT-2, Jira:MYPROJECT-3)
+			st.Run("locked", func(t *testing.T) {})
+		})
+	}
+
+	// Trace(Jira:MYPROJECT-4)
+	func Testlowercase(t *testing.T) {
+	}
+
+	// Trace(Jira:MYPROJECT-5)
+	func TestHelper(t *testing.T, name string) {
+	}
+
+	func TestWithoutTrace(t *testing.T) {
+		// Trace(Jira:MYPROJECT-6)
+		t.Run(name, func(t *testing.T) {})
+	}
+	`,
+		expectedResult: []TestBacklog{
+			{Test: Test{ClassName: "example.com/mod/pkg", Method: "TestLogin"},
+				BacklogItem: []BacklogItem{{ID: "MYPROJECT-1", Source: Jira}}},
+			{Test: Test{ClassName: "example.com/mod/pkg", Method: "TestLogin/invalid_user"},
+				BacklogItem: []BacklogItem{{ID: "myorg/myRepo#1", Source: Github}}},
+			{Test: Test{ClassName: "example.com/mod/pkg", Method: "TestLogin/invalid_user/locked"},
+				BacklogItem: []BacklogItem{{ID: "MYPROJECT-2", Source: Jira}, {ID: "MYPROJECT-3", Source: Jira}}},
+		}},
+	{input: `package pkg_test
+
+	import (
+		gotesting "testing"
+	)
+
+	// Some test
+	//
+	// Trace(GitHub:myorg/myRepo#2)
+	func Test(t *gotesting.T) {
+	}
+	`,
+		expectedResult: []TestBacklog{
+			{Test: Test{ClassName: "example.com/mod/pkg", Method: "Test"},
+				BacklogItem: []BacklogItem{{ID: "myorg/myRepo#2", Source: Github}}},
+		}},
+	{input: `package pkg
+
+	// Trace(Jira:MYPROJECT-7)
+	func TestNotImportingTesting(t *T) {
+	}
+	`,
+		expectedResult: []TestBacklog{}},
+}
+
+func TestGoParsing(t *testing.T) {
+
+	cfg := new(utils.Config)
+	cfg.Mapping.Local = "NonPersistedMappingFileForTesting"
+
+	var sc = utils.Sourcecode{Language: "go", Local: "/tmp/test/"}
+	var file = os.NewFile(0, "/tmp/test/pkg/pkg_test.go")
+
+	for i, mapping := range testGoCode {
+		tb := parseGo(strings.NewReader(mapping.input), *cfg, sc, file, "example.com/mod/pkg")
+		if !compareTestBacklog(tb, mapping.expectedResult) {
+			t.Error("Comparism of Go Code (No. " + strconv.Itoa(i) + "): \n" + mapping.input + "\n with expected result failed.")
+		}
+	}
+
+}
+
+func TestRewriteGoSubtestName(t *testing.T) {
+	if name := rewriteGoSubtestName("user with\ttab\x01"); name != `user_with_tab\x01` {
+		t.Error("Invalid subtest name: ", name)
+	}
+}
+
+func TestGetGoImportPath(t *testing.T) {
+	root, err := ioutil.TempDir("", "ctm-go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	module := filepath.Join(root, "module")
+	os.MkdirAll(filepath.Join(module, "sub", "pkg"), 0755)
+	os.MkdirAll(filepath.Join(root, "other"), 0755)
+	ioutil.WriteFile(filepath.Join(module, "go.mod"), []byte("module example.com/mod // comment\n\ngo 1.12\n"), 0644)
+
+	if path := getGoImportPath(root, module); path != "example.com/mod" {
+		t.Error("Invalid import path for module root: ", path)
+	}
+	if path := getGoImportPath(root, filepath.Join(module, "sub", "pkg")); path != "example.com/mod/sub/pkg" {
+		t.Error("Invalid import path for package: ", path)
+	}
+	if path := getGoImportPath(root, filepath.Join(root, "other")); path != "other" {
+		t.Error("Invalid import path without go.mod: ", path)
+	}
+}
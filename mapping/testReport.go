@@ -0,0 +1,178 @@
+package mapping
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/SAP/quality-continuous-traceability-monitor/testreport"
+	"github.com/SAP/quality-continuous-traceability-monitor/utils"
+	"github.com/golang/glog"
+)
+
+// Used to check whether a test report tag is a backlog item reference (e.g. Jira:MYJIRAPROJECT-1 or GitHub:myOrg/myRepo#1)
+var reBacklogItemTag = regexp.MustCompile(`^\s*(?i:github|jira)\s*:\s*[a-zA-Z0-9\-\/#\_]+\s*$`)
+
+// TestReportMapping creates test to backlog item mappings from the tags test cases carry in their test reports
+// (e.g. Allure links or Robot Framework tags). Pls. note, that this class does NOT implement the mapping.Parser interface, as it works on
+// the parsed test reports instead of the sourcecode
+type TestReportMapping struct {
+	RequirementProperties []string                  // Names of test case properties (e.g. xunit property elements) holding backlog items
+	TestNameExtractors    []utils.TestNameExtractor // Extract backlog items from test names (e.g. should login [Jira:AUTH-12])
+}
+
+// Parse test suites to seek for test cases which are tagged with a backlog item (e.g. Jira:MYJIRAPROJECT-1) or
+// a traceability marker (e.g. Trace(Jira:MYJIRAPROJECT-1)). Values of the requirement properties might list multiple
+// backlog items (e.g. Jira:MYJIRAPROJECT-1, Jira:MYJIRAPROJECT-2)
+func (trm TestReportMapping) Parse(testSuites []testreport.TestSuite) []TestBacklog {
+
+	defer utils.TimeTrack(time.Now(), "Read mapping from test reports")
+
+	var tb = []TestBacklog{}
+	var tbIndex = make(map[Test]int) // Same test case might be part of multiple test reports
+	var extractors = compileTestNameExtractors(trm.TestNameExtractors)
+
+	for _, ts := range testSuites {
+		for _, tc := range ts.TestCase {
+			var bli []BacklogItem
+			for _, tag := range tc.Tags {
+				if reBacklogItemTag.MatchString(tag) || reTraceMarker.MatchString(tag) {
+					bli = appendMissingBacklogItems(bli, GetBacklogItem(tag))
+				}
+			}
+			for _, name := range trm.RequirementProperties {
+				for _, value := range tc.Properties[name] {
+					bli = appendMissingBacklogItems(bli, getBacklogItemsFromProperty(tc, name, value))
+				}
+			}
+			for _, extractor := range extractors {
+				bli = appendMissingBacklogItems(bli, extractor.extract(tc.ClassName))
+				bli = appendMissingBacklogItems(bli, extractor.extract(tc.MethodName))
+			}
+			if bli == nil {
+				continue
+			}
+
+			test := Test{ClassName: tc.ClassName, Method: tc.MethodName}
+			i, found := tbIndex[test]
+			if !found {
+				tbIndex[test] = len(tb)
+				tb = append(tb, TestBacklog{Test: test, BacklogItem: bli})
+				continue
+			}
+			tb[i].BacklogItem = appendMissingBacklogItems(tb[i].BacklogItem, bli)
+		}
+	}
+
+	return tb
+
+}
+
+func getBacklogItemsFromProperty(tc *testreport.TestCase, name string, value string) []BacklogItem {
+	if reTraceMarker.MatchString(value) {
+		return GetBacklogItem(value)
+	}
+
+	var bli []BacklogItem
+	for _, item := range strings.Split(value, ",") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		if !reBacklogItemTag.MatchString(item) {
+			glog.Warning("Property ", name, " of test ", tc.ClassName, ".", tc.MethodName, " doesn't reference a backlog item (e.g. Jira:MYJIRAPROJECT-1): ", item)
+			continue
+		}
+		bli = append(bli, GetBacklogItem(item)...)
+	}
+	return bli
+}
+
+// testNameExtractor compiled utils.TestNameExtractor
+type testNameExtractor struct {
+	pattern     *regexp.Regexp
+	backlogItem string
+}
+
+func compileTestNameExtractors(extractors []utils.TestNameExtractor) []testNameExtractor {
+	var compiled []testNameExtractor
+	for _, extractor := range extractors {
+		pattern, err := regexp.Compile(extractor.Pattern)
+		if err != nil {
+			glog.Error("Invalid test name extractor pattern ", extractor.Pattern, ": ", err)
+			continue
+		}
+		compiled = append(compiled, testNameExtractor{pattern, extractor.BacklogItem})
+	}
+	return compiled
+}
+
+// extract the backlog items from a test (class or method) name. Each match of the pattern is expanded to a backlog
+// item reference (e.g. Jira:AUTH-12), which is then read just like a trace in the sourcecode
+func (tne testNameExtractor) extract(name string) []BacklogItem {
+	var bli []BacklogItem
+	for _, match := range tne.pattern.FindAllStringSubmatchIndex(name, -1) {
+		var item string
+		switch {
+		case tne.backlogItem != "":
+			item = string(tne.pattern.ExpandString(nil, tne.backlogItem, name, match))
+		case len(match) > 2 && match[2] != -1:
+			item = name[match[2]:match[3]]
+		default:
+			item = name[match[0]:match[1]]
+		}
+		bli = appendMissingBacklogItems(bli, GetBacklogItem(item))
+	}
+	return bli
+}
+
+// MergeTestBacklog adds the additional test to backlog item mappings (of single test cases, as found in test reports)
+// to the given ones. Backlog items which are already mapped to a matching test (the very same test or e.g. its whole
+// test class) are not added again, so that a test isn't traced twice for the same backlog item
+func MergeTestBacklog(tb []TestBacklog, additional []TestBacklog) []TestBacklog {
+
+	var merged = tb
+	for _, atb := range additional {
+		var bli = atb.BacklogItem
+		tc := &testreport.TestCase{ClassName: atb.Test.ClassName, MethodName: atb.Test.Method}
+		for i := range tb {
+			if tb[i].Matches(tc) {
+				bli = removeBacklogItems(bli, tb[i].BacklogItem)
+			}
+		}
+		if len(bli) > 0 {
+			atb.BacklogItem = bli
+			merged = append(merged, atb)
+		}
+	}
+
+	return merged
+
+}
+
+func containsBacklogItem(bli []BacklogItem, item BacklogItem) bool {
+	for _, current := range bli {
+		if current == item {
+			return true
+		}
+	}
+	return false
+}
+
+func appendMissingBacklogItems(bli []BacklogItem, additional []BacklogItem) []BacklogItem {
+	for _, item := range additional {
+		if !containsBacklogItem(bli, item) {
+			bli = append(bli, item)
+		}
+	}
+	return bli
+}
+
+func removeBacklogItems(bli []BacklogItem, remove []BacklogItem) []BacklogItem {
+	var remaining []BacklogItem
+	for _, item := range bli {
+		if !containsBacklogItem(remove, item) {
+			remaining = append(remaining, item)
+		}
+	}
+	return remaining
+}
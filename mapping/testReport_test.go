@@ -0,0 +1,141 @@
+package mapping
+
+import (
+	"testing"
+
+	"github.com/SAP/quality-continuous-traceability-monitor/testreport"
+	"github.com/SAP/quality-continuous-traceability-monitor/utils"
+	"github.com/go-test/deep"
+)
+
+func TestParseTestReportMapping(t *testing.T) {
+	testSuites := []testreport.TestSuite{
+		{Name: "com.sap.ctm.testing.LoginTest", TestCase: []*testreport.TestCase{
+			{ClassName: "com.sap.ctm.testing.LoginTest", MethodName: "successfulLogin", Tags: []string{"Jira:MYJIRAPROJECT-1", "smoke", "Jira:MYJIRAPROJECT-1"}},
+			{ClassName: "com.sap.ctm.testing.LoginTest", MethodName: "failedLogin", Tags: []string{"Trace(GitHub:myOrg/myRepo#5, Jira:MYJIRAPROJECT-2)"}},
+			{ClassName: "com.sap.ctm.testing.LoginTest", MethodName: "untagged"},
+		}},
+		{Name: "com.sap.ctm.testing.LoginTest", TestCase: []*testreport.TestCase{
+			{ClassName: "com.sap.ctm.testing.LoginTest", MethodName: "successfulLogin", Tags: []string{"jira:MYJIRAPROJECT-3"}},
+		}},
+	}
+
+	expected := []TestBacklog{
+		{Test: Test{ClassName: "com.sap.ctm.testing.LoginTest", Method: "successfulLogin"},
+			BacklogItem: []BacklogItem{{ID: "MYJIRAPROJECT-1", Source: Jira}, {ID: "MYJIRAPROJECT-3", Source: Jira}}},
+		{Test: Test{ClassName: "com.sap.ctm.testing.LoginTest", Method: "failedLogin"},
+			BacklogItem: []BacklogItem{{ID: "myOrg/myRepo#5", Source: Github}, {ID: "MYJIRAPROJECT-2", Source: Jira}}},
+	}
+
+	trm := TestReportMapping{}
+	if diff := deep.Equal(trm.Parse(testSuites), expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestParseTestReportMappingProperties(t *testing.T) {
+	testSuites := []testreport.TestSuite{
+		{Name: "XUNIT.Test", TestCase: []*testreport.TestCase{
+			{ClassName: "XUNIT.Test", MethodName: "someTest", Tags: []string{"Jira:MYJIRAPROJECT-1"},
+				Properties: map[string][]string{"requirement": {"Jira:MYJIRAPROJECT-1, Jira:MYJIRAPROJECT-2"}, "component": {"Jira:MYJIRAPROJECT-3"}}},
+			{ClassName: "XUNIT.Test", MethodName: "otherTest", Properties: map[string][]string{"req": {"Trace(GitHub:myOrg/myRepo#5)", "not-a-backlog-item"}}},
+			{ClassName: "XUNIT.Test", MethodName: "noProperties"},
+		}},
+	}
+
+	expected := []TestBacklog{
+		{Test: Test{ClassName: "XUNIT.Test", Method: "someTest"},
+			BacklogItem: []BacklogItem{{ID: "MYJIRAPROJECT-1", Source: Jira}, {ID: "MYJIRAPROJECT-2", Source: Jira}}},
+		{Test: Test{ClassName: "XUNIT.Test", Method: "otherTest"},
+			BacklogItem: []BacklogItem{{ID: "myOrg/myRepo#5", Source: Github}}},
+	}
+
+	trm := TestReportMapping{RequirementProperties: []string{"requirement", "req"}}
+	if diff := deep.Equal(trm.Parse(testSuites), expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestParseTestReportMappingTestNames(t *testing.T) {
+	testSuites := []testreport.TestSuite{
+		{Name: "login", TestCase: []*testreport.TestCase{
+			{ClassName: "login", MethodName: "should login [Jira:AUTH-12] [GitHub:myOrg/myRepo#5]"},
+			{ClassName: "tests.test_login", MethodName: "test_AUTH_13_login"},
+			{ClassName: "tests.test_AUTH_14_logout", MethodName: "test_AUTH_13_logout"},
+			{ClassName: "login", MethodName: "should logout"},
+		}},
+	}
+
+	expected := []TestBacklog{
+		{Test: Test{ClassName: "login", Method: "should login [Jira:AUTH-12] [GitHub:myOrg/myRepo#5]"},
+			BacklogItem: []BacklogItem{{ID: "AUTH-12", Source: Jira}, {ID: "myOrg/myRepo#5", Source: Github}}},
+		{Test: Test{ClassName: "tests.test_login", Method: "test_AUTH_13_login"},
+			BacklogItem: []BacklogItem{{ID: "AUTH-13", Source: Jira}}},
+		{Test: Test{ClassName: "tests.test_AUTH_14_logout", Method: "test_AUTH_13_logout"},
+			BacklogItem: []BacklogItem{{ID: "AUTH-14", Source: Jira}, {ID: "AUTH-13", Source: Jira}}},
+	}
+
+	trm := TestReportMapping{TestNameExtractors: []utils.TestNameExtractor{
+		{Pattern: `\[((?:Jira|GitHub):[^\]]+)\]`},
+		{Pattern: `test_([A-Z]+)_([0-9]+)_`, BacklogItem: "Jira:$1-$2"},
+	}}
+	if diff := deep.Equal(trm.Parse(testSuites), expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestMergeTestBacklog(t *testing.T) {
+	tb := []TestBacklog{
+		{Test: Test{ClassName: "com.sap.ctm.testing.LoginTest", Method: "successfulLogin", FileURL: "LoginTest.java"},
+			BacklogItem: []BacklogItem{{ID: "MYJIRAPROJECT-1", Source: Jira}}},
+	}
+	additional := []TestBacklog{
+		{Test: Test{ClassName: "com.sap.ctm.testing.LoginTest", Method: "successfulLogin"},
+			BacklogItem: []BacklogItem{{ID: "MYJIRAPROJECT-1", Source: Jira}, {ID: "MYJIRAPROJECT-3", Source: Jira}}},
+		{Test: Test{ClassName: "com.sap.ctm.testing.LoginTest", Method: "failedLogin"},
+			BacklogItem: []BacklogItem{{ID: "MYJIRAPROJECT-2", Source: Jira}}},
+	}
+
+	expected := []TestBacklog{
+		{Test: Test{ClassName: "com.sap.ctm.testing.LoginTest", Method: "successfulLogin", FileURL: "LoginTest.java"},
+			BacklogItem: []BacklogItem{{ID: "MYJIRAPROJECT-1", Source: Jira}}},
+		{Test: Test{ClassName: "com.sap.ctm.testing.LoginTest", Method: "successfulLogin"},
+			BacklogItem: []BacklogItem{{ID: "MYJIRAPROJECT-3", Source: Jira}}},
+		{Test: Test{ClassName: "com.sap.ctm.testing.LoginTest", Method: "failedLogin"},
+			BacklogItem: []BacklogItem{{ID: "MYJIRAPROJECT-2", Source: Jira}}},
+	}
+
+	if diff := deep.Equal(MergeTestBacklog(tb, additional), expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestMergeTestBacklogWithClassMapping(t *testing.T) {
+	tb := []TestBacklog{
+		{Test: Test{ClassName: "com.sap.ctm.testing.LoginTest", FileURL: "LoginTest.java"},
+			BacklogItem: []BacklogItem{{ID: "MYJIRAPROJECT-1", Source: Jira}}},
+		{Test: Test{ClassName: "Login", Method: "Login with user"}, TestCaseMatcher: &GaugeTestCaseMatcher{},
+			BacklogItem: []BacklogItem{{ID: "MYJIRAPROJECT-4", Source: Jira}}},
+	}
+	additional := []TestBacklog{
+		{Test: Test{ClassName: "com.sap.ctm.testing.LoginTest", Method: "successfulLogin"},
+			BacklogItem: []BacklogItem{{ID: "MYJIRAPROJECT-1", Source: Jira}, {ID: "MYJIRAPROJECT-2", Source: Jira}}},
+		{Test: Test{ClassName: "com.sap.ctm.testing.OtherTest", Method: "successfulLogin"},
+			BacklogItem: []BacklogItem{{ID: "MYJIRAPROJECT-1", Source: Jira}}},
+		{Test: Test{ClassName: "Login", Method: "Login with user 2"},
+			BacklogItem: []BacklogItem{{ID: "MYJIRAPROJECT-4", Source: Jira}}},
+	}
+
+	expected := []TestBacklog{
+		tb[0],
+		tb[1],
+		{Test: Test{ClassName: "com.sap.ctm.testing.LoginTest", Method: "successfulLogin"},
+			BacklogItem: []BacklogItem{{ID: "MYJIRAPROJECT-2", Source: Jira}}},
+		{Test: Test{ClassName: "com.sap.ctm.testing.OtherTest", Method: "successfulLogin"},
+			BacklogItem: []BacklogItem{{ID: "MYJIRAPROJECT-1", Source: Jira}}},
+	}
+
+	if diff := deep.Equal(MergeTestBacklog(tb, additional), expected); diff != nil {
+		t.Error(diff)
+	}
+}
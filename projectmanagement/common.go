@@ -6,6 +6,7 @@ import (
 	"github.com/SAP/quality-continuous-traceability-monitor/mapping"
 	"github.com/SAP/quality-continuous-traceability-monitor/testreport"
 	"github.com/SAP/quality-continuous-traceability-monitor/utils"
+	"html"
 	"io"
 	"os"
 	"strconv"
@@ -21,11 +22,27 @@ const CacheFilename string = ".commentCache"
 
 // TraceTest maps an automated test (ClassName/MethodName) to it's result (TestResult), with the test result report (e.g. XUNIT) and the test sourcecode file
 type TraceTest struct {
-	SourceFile string
+	SourceFile  string
+	ReportFile  string
+	ClassName   string
+	MethodName  string
+	Variant     string
+	Environment string // Environment the test was run in (label of the test report, e.g. linux-jdk17)
+	TestResult  int
+	Message     string        // Failure, error or skip message
+	StackTrace  string        // Stack trace of the failure
+	Duration    time.Duration // Duration of the test (0 if not reported)
+	Stdout      string        // (Truncated) standard output of the test
+	Stderr      string        // (Truncated) standard error output of the test
+	Attempts    []TestAttempt // Superseded runs of the test (e.g. in retried CI jobs)
+}
+
+// TestAttempt is a superseded run of a test (e.g. in a retried CI job), which was merged into the TraceTest
+type TestAttempt struct {
 	ReportFile string
-	ClassName  string
-	MethodName string
 	TestResult int
+	Timestamp  time.Time
+	Message    string
 }
 
 // Trace maps a TraceTest (automated test and result) to a BacklogItem
@@ -90,22 +107,116 @@ func GetGHBranch(cfg utils.Config) string {
 
 }
 
-// GetNumberOfSuccessfulTestedTraces returns the number of successfully tested requirements
-func GetNumberOfSuccessfulTestedTraces(traces []Trace) int {
+// EnvironmentResult is the test result of a requirement in one environment (see label of the test report)
+type EnvironmentResult struct {
+	Environment string
+	Result      int
+}
+
+// GetTraceResult returns the overall test result of a requirement (trace). False is returned if the requirement has no tests.
+// The most severe result of all tests of a requirement is the result of the requirement (see testreport.GetSeverity). A
+// requirement is therefore only flaky, if all its tests passed (at least on retry).
+// The results of multiple environments are combined according to the configuration (testResults.environmentPolicy): Either
+// the requirement needs to pass in all environments (the most severe result counts) or in any (the least severe result counts)
+func GetTraceResult(trace Trace, cfg utils.Config) (int, bool) {
+
+	if trace.TraceTests == nil {
+		return 0, false
+	}
+	if cfg.TestResults.EnvironmentPolicy != utils.EnvironmentPolicyAny {
+		return getMostSevereResult(trace.TraceTests), true
+	}
+
+	environmentResults := GetEnvironmentResults(trace)
+	var result = environmentResults[0].Result
+	for _, environmentResult := range environmentResults[1:] {
+		if testreport.GetSeverity(environmentResult.Result) > testreport.GetSeverity(result) {
+			result = environmentResult.Result
+		}
+	}
+	return result, true
+
+}
+
+// GetEnvironmentResults returns the test result of a requirement (trace) per environment, in the order the environments
+// appear in the tests. Tests of test reports without label belong to the environment ""
+func GetEnvironmentResults(trace Trace) []EnvironmentResult {
+
+	var environments []string
+	var environmentTests = make(map[string][]TraceTest)
+	for _, test := range trace.TraceTests {
+		if _, found := environmentTests[test.Environment]; !found {
+			environments = append(environments, test.Environment)
+		}
+		environmentTests[test.Environment] = append(environmentTests[test.Environment], test)
+	}
+
+	var results []EnvironmentResult
+	for _, environment := range environments {
+		results = append(results, EnvironmentResult{environment, getMostSevereResult(environmentTests[environment])})
+	}
+	return results
+
+}
+
+// hasEnvironments checks whether the tests of a requirement (trace) were run in labeled environments
+func hasEnvironments(trace Trace) bool {
+	for _, test := range trace.TraceTests {
+		if test.Environment != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func getMostSevereResult(tests []TraceTest) int {
+	var result = testreport.SUCCESS
+	for _, test := range tests {
+		if testreport.GetSeverity(test.TestResult) < testreport.GetSeverity(result) {
+			result = test.TestResult
+		}
+	}
+	return result
+}
+
+// Name of an environment in the reports
+func getEnvironmentName(environment string) string {
+	if environment == "" {
+		return "unlabeled"
+	}
+	return environment
+}
+
+// Environment and variant the test was run in (e.g. linux-jdk17, webkit)
+func (tt TraceTest) getVariant() string {
+	if tt.Environment != "" && tt.Variant != "" {
+		return tt.Environment + ", " + tt.Variant
+	}
+	return tt.Environment + tt.Variant
+}
+
+// GetNumberOfTracesWithResult returns the number of tested requirements with the given overall test result
+func GetNumberOfTracesWithResult(traces []Trace, result int, cfg utils.Config) int {
+
+	var req int
+	for _, trace := range traces {
+		if traceResult, tested := GetTraceResult(trace, cfg); tested && traceResult == result {
+			req++
+		}
+	}
+
+	return req
+
+}
+
+// GetNumberOfSuccessfulTestedTraces returns the number of successfully tested requirements. Depending on the
+// configuration (testResults.flakyAsPassed) requirements with flaky tests are counted as successful
+func GetNumberOfSuccessfulTestedTraces(traces []Trace, cfg utils.Config) int {
 
 	var successfulReq int
 	for _, trace := range traces {
-		if trace.TraceTests != nil { // Trace has tests
-			var allSuccessfull = true
-			for _, test := range trace.TraceTests {
-				if test.TestResult != testreport.SUCCESS {
-					allSuccessfull = false
-					break
-				}
-			}
-			if allSuccessfull {
-				successfulReq++
-			}
+		if isTraceSuccessful(trace, cfg) {
+			successfulReq++
 		}
 	}
 
@@ -113,6 +224,33 @@ func GetNumberOfSuccessfulTestedTraces(traces []Trace) int {
 
 }
 
+func isTraceSuccessful(trace Trace, cfg utils.Config) bool {
+
+	traceResult, tested := GetTraceResult(trace, cfg)
+	return tested && (traceResult == testreport.SUCCESS || (traceResult == testreport.FLAKY && cfg.TestResults.FlakyAsPassed))
+
+}
+
+// GetResultName returns the name of a test result (as used in the JSON report)
+func GetResultName(result int) string {
+
+	switch result {
+	case testreport.SUCCESS:
+		return "success"
+	case testreport.FAILURE:
+		return "failure"
+	case testreport.ERROR:
+		return "error"
+	case testreport.SKIPPED:
+		return "skipped"
+	case testreport.FLAKY:
+		return "flaky"
+	default:
+		return "unknown"
+	}
+
+}
+
 // CreateHTMLReport creates a HTML report file (and returns the file reference)
 // filepath - the dirpath where to create the file
 // traces - list of all traces from the sourcecode
@@ -169,6 +307,28 @@ func CreateHTMLReport(filepath string, traces []Trace, cfg utils.Config, fullRep
                                .ok {
                                  background-color: #e1f5a9;
                                  padding: 5px
+                               }
+                               .error {
+                                 background-color: #ffe9cc;
+                                 padding: 5px
+                               }
+                               .skipped {
+                                 background-color: #eeeeee;
+                                 padding: 5px
+                               }
+                               .flaky {
+                                 background-color: #fff7cc;
+                                 padding: 5px
+                               }
+                               .duration {
+                                 color: #666666;
+                               }
+                               details pre {
+                                 font-family: "Courier New", "Lucida Console";
+                                 font-size: small;
+                                 white-space: pre-wrap;
+                                 max-height: 300px;
+                                 overflow: auto;
                                }
 							   .green{
 								 color: #4FB810;
@@ -183,7 +343,11 @@ func CreateHTMLReport(filepath string, traces []Trace, cfg utils.Config, fullRep
                            <h1>Full Software Requirement Test Report</h1>
 						   %programAndVersion%
 	                       <div><h3>Total number of requirements: %totalNumberOfRequirements%<br/>
-	                            Total number of successful requirements: %totalNumberOfSuccessfulRequirements%</h3></div>
+	                            Total number of successful requirements: %totalNumberOfSuccessfulRequirements%<br/>
+	                            Total number of failed requirements: %totalNumberOfFailedRequirements%<br/>
+	                            Total number of requirements with errors: %totalNumberOfErroneousRequirements%<br/>
+	                            Total number of skipped requirements: %totalNumberOfSkippedRequirements%<br/>
+	                            Total number of flaky requirements: %totalNumberOfFlakyRequirements%</h3></div>
 	                       <p><div style="color:#666666"><i>Snapshot taken: %timestamp%</i></div></p>
                            <hr/>
                            <table>
@@ -202,26 +366,16 @@ func CreateHTMLReport(filepath string, traces []Trace, cfg utils.Config, fullRep
 	f.WriteString(header)
 
 	var table string
-	var successfulReq int
 	for i, trace := range traces {
 		table = table + "<tr><td>" + strconv.Itoa(i+1) + "</td>"
-		table = table + "<td><a href=\"" + trace.BacklogItem.GetIssueURL(cfg) + "\" target=\"_blank\">%backlogItem%</a></td>"
+		table = table + "<td><a href=\"" + trace.BacklogItem.GetIssueURL(cfg) + "\" target=\"_blank\">%backlogItem%</a>%environments%</td>"
 		var tests = "<td><div><ul class=\"nobullets\">"
-		var allSuccessful = true
 		if trace.TraceTests == nil { // We have traces, but no test results
-			allSuccessful = false
 			tests = tests + "<li class=\"notok\"><b>Missing</b>"
 		} else {
 			for _, test := range trace.TraceTests {
-				if test.TestResult == testreport.SUCCESS {
-					tests = tests + "<li class=\"ok\"><b>OK&nbsp;&nbsp;&nbsp;&nbsp;&nbsp;&nbsp;&nbsp;</b>"
-				} else if test.TestResult == testreport.SKIPPED {
-					allSuccessful = false
-					tests = tests + "<li class=\"notok\"><b>not OK</b>"
-				} else {
-					allSuccessful = false
-					tests = tests + "<li class=\"notok\"><b>not OK</b>"
-				}
+				class, label := getHTMLResult(test.TestResult)
+				tests = tests + "<li class=\"" + class + "\"><b>" + label + "</b>"
 				var sourceCodeLink string
 				if test.SourceFile != "" {
 					sourceCodeLink = "<a href=\"" + test.SourceFile + "\" target=\"_blank\">"
@@ -233,15 +387,40 @@ func CreateHTMLReport(filepath string, traces []Trace, cfg utils.Config, fullRep
 				if sourceCodeLink != "" {
 					tests = tests + "</a>"
 				}
+				if variant := test.getVariant(); variant != "" {
+					tests = tests + " [" + html.EscapeString(variant) + "]"
+				}
+				if test.Duration > 0 {
+					tests = tests + " <span class=\"duration\">(" + test.getDuration() + ")</span>"
+				}
+				if details := test.getDetails(); details != "" {
+					tests = tests + "<details><summary>" + html.EscapeString(test.getSummary()) + "</summary><pre>" + html.EscapeString(details) + "</pre></details>"
+				}
 				tests = tests + "</li>"
 			}
 		}
-		if allSuccessful {
-			successfulReq++
+		traceResult, _ := GetTraceResult(trace, cfg)
+		if traceResult == testreport.FLAKY { // Flaky requirements are highlighted, even if they count as successful
+			table = strings.Replace(table, "%backlogItem%", "<span class=\"flaky\">"+trace.BacklogItem.ID+"</span>", 1)
+		} else if isTraceSuccessful(trace, cfg) {
 			table = strings.Replace(table, "%backlogItem%", trace.BacklogItem.ID, 1)
 		} else {
-			table = strings.Replace(table, "%backlogItem%", "<span class=\"notok\">"+trace.BacklogItem.ID+"</span>", 1)
+			class := "notok"
+			if traceResult == testreport.ERROR || traceResult == testreport.SKIPPED {
+				class, _ = getHTMLResult(traceResult)
+			}
+			table = strings.Replace(table, "%backlogItem%", "<span class=\""+class+"\">"+trace.BacklogItem.ID+"</span>", 1)
+		}
+		var environments string
+		if hasEnvironments(trace) { // Show the result per environment below the backlog item
+			environments = "<ul class=\"nobullets\">"
+			for _, environmentResult := range GetEnvironmentResults(trace) {
+				class, _ := getHTMLResult(environmentResult.Result)
+				environments = environments + "<li class=\"" + class + "\">" + html.EscapeString(getEnvironmentName(environmentResult.Environment)) + "</li>"
+			}
+			environments = environments + "</ul>"
 		}
+		table = strings.Replace(table, "%environments%", environments, 1)
 		table = table + tests + "</div></ul></tr>"
 	}
 
@@ -253,6 +432,11 @@ func CreateHTMLReport(filepath string, traces []Trace, cfg utils.Config, fullRep
 	data = strings.Replace(data, "%programAndVersion%", version, 1)
 	data = strings.Replace(data, "%tabledata%", table, 1)
 	data = strings.Replace(data, "%totalNumberOfRequirements%", strconv.FormatInt(int64(len(traces)), 10), 1)
+	data = strings.Replace(data, "%totalNumberOfFailedRequirements%", strconv.Itoa(GetNumberOfTracesWithResult(traces, testreport.FAILURE, cfg)), 1)
+	data = strings.Replace(data, "%totalNumberOfErroneousRequirements%", strconv.Itoa(GetNumberOfTracesWithResult(traces, testreport.ERROR, cfg)), 1)
+	data = strings.Replace(data, "%totalNumberOfSkippedRequirements%", strconv.Itoa(GetNumberOfTracesWithResult(traces, testreport.SKIPPED, cfg)), 1)
+	data = strings.Replace(data, "%totalNumberOfFlakyRequirements%", strconv.Itoa(GetNumberOfTracesWithResult(traces, testreport.FLAKY, cfg)), 1)
+	successfulReq := GetNumberOfSuccessfulTestedTraces(traces, cfg)
 	if successfulReq == len(traces) {
 		data = strings.Replace(data, "%totalNumberOfSuccessfulRequirements%", "<span class=\"green\">"+strconv.FormatInt(int64(successfulReq), 10)+"</span>", 1)
 	} else {
@@ -294,6 +478,17 @@ func CreateJSONReport(filepath string, traces []Trace, cfg utils.Config) *os.Fil
 	for i, trace := range traces {
 		f.WriteString(INTENT + "\"" + trace.BacklogItem.ID + "\": {\n")
 		f.WriteString(INTENT + INTENT + "\"link\": \"" + trace.BacklogItem.GetIssueURL(cfg) + "\",\n")
+		if traceResult, tested := GetTraceResult(trace, cfg); tested {
+			f.WriteString(INTENT + INTENT + "\"result\": \"" + GetResultName(traceResult) + "\",\n")
+		}
+		if hasEnvironments(trace) {
+			var environments []string
+			for _, environmentResult := range GetEnvironmentResults(trace) {
+				name, _ := json.Marshal(getEnvironmentName(environmentResult.Environment))
+				environments = append(environments, string(name)+": \""+GetResultName(environmentResult.Result)+"\"")
+			}
+			f.WriteString(INTENT + INTENT + "\"environments\": {" + strings.Join(environments, ", ") + "},\n")
+		}
 		f.WriteString(INTENT + INTENT + "\"test_cases\": [\n")
 		for j, testCase := range trace.TraceTests {
 			f.WriteString(INTENT + INTENT + INTENT + "{\n")
@@ -309,6 +504,18 @@ func CreateJSONReport(filepath string, traces []Trace, cfg utils.Config) *os.Fil
 			if testCase.SourceFile != "" {
 				f.WriteString(INTENT + INTENT + INTENT + INTENT + "\"test_source\": \"" + testCase.SourceFile + "\",\n")
 			}
+			writeJSONString(f, INTENT+INTENT+INTENT+INTENT, "test_variant", testCase.Variant)
+			writeJSONString(f, INTENT+INTENT+INTENT+INTENT, "test_environment", testCase.Environment)
+			if testCase.Duration > 0 {
+				f.WriteString(INTENT + INTENT + INTENT + INTENT + "\"duration_seconds\": " + strconv.FormatFloat(testCase.Duration.Seconds(), 'f', -1, 64) + ",\n")
+			}
+			// Messages, stack traces and output might contain any character, therefore they are JSON encoded
+			writeJSONString(f, INTENT+INTENT+INTENT+INTENT, "message", testCase.Message)
+			writeJSONString(f, INTENT+INTENT+INTENT+INTENT, "stack_trace", testCase.StackTrace)
+			writeJSONString(f, INTENT+INTENT+INTENT+INTENT, "stdout", testCase.Stdout)
+			writeJSONString(f, INTENT+INTENT+INTENT+INTENT, "stderr", testCase.Stderr)
+			writeJSONAttempts(f, INTENT+INTENT+INTENT+INTENT, testCase.Attempts)
+			f.WriteString(INTENT + INTENT + INTENT + INTENT + "\"result\": \"" + GetResultName(testCase.TestResult) + "\",\n")
 			if testCase.TestResult == testreport.SUCCESS {
 				f.WriteString(INTENT + INTENT + INTENT + INTENT + "\"passed\": true,\n")
 			} else {
@@ -340,6 +547,88 @@ func CreateJSONReport(filepath string, traces []Trace, cfg utils.Config) *os.Fil
 
 }
 
+// CSS class and label of a test result in the HTML report
+func getHTMLResult(result int) (string, string) {
+	switch result {
+	case testreport.SUCCESS:
+		return "ok", "OK&nbsp;&nbsp;&nbsp;&nbsp;&nbsp;&nbsp;&nbsp;"
+	case testreport.ERROR:
+		return "error", "Error&nbsp;&nbsp;"
+	case testreport.SKIPPED:
+		return "skipped", "Skipped"
+	case testreport.FLAKY:
+		return "flaky", "Flaky&nbsp;&nbsp;"
+	default:
+		return "notok", "not OK"
+	}
+}
+
+// Write a (non empty) string as JSON encoded name/value pair
+func writeJSONString(f *os.File, intent string, name string, value string) {
+	if value == "" {
+		return
+	}
+	encoded, _ := json.Marshal(value)
+	f.WriteString(intent + "\"" + name + "\": " + string(encoded) + ",\n")
+}
+
+// Write the superseded runs of a test (if any) as JSON array
+func writeJSONAttempts(f *os.File, intent string, attempts []TestAttempt) {
+	if len(attempts) == 0 {
+		return
+	}
+	type jsonAttempt struct {
+		ReportFile string `json:"report_file"`
+		Result     string `json:"result"`
+		Timestamp  string `json:"timestamp,omitempty"`
+		Message    string `json:"message,omitempty"`
+	}
+	var encodedAttempts []string
+	for _, attempt := range attempts {
+		var timestamp string
+		if !attempt.Timestamp.IsZero() {
+			timestamp = attempt.Timestamp.UTC().Format(time.RFC3339)
+		}
+		encoded, _ := json.Marshal(jsonAttempt{attempt.ReportFile, GetResultName(attempt.TestResult), timestamp, attempt.Message})
+		encodedAttempts = append(encodedAttempts, string(encoded))
+	}
+	f.WriteString(intent + "\"superseded_attempts\": [" + strings.Join(encodedAttempts, ", ") + "],\n")
+}
+
+// Duration of the test rounded to milliseconds (e.g. 1.25s)
+func (tt TraceTest) getDuration() string {
+	return tt.Duration.Round(time.Millisecond).String()
+}
+
+// Short summary of the test details (the failure message or, if there is none, the first line of the stack trace)
+func (tt TraceTest) getSummary() string {
+	if tt.Message != "" {
+		return strings.TrimSpace(strings.SplitN(tt.Message, "\n", 2)[0])
+	}
+	if tt.StackTrace != "" {
+		return strings.TrimSpace(strings.SplitN(tt.StackTrace, "\n", 2)[0])
+	}
+	return "Output"
+}
+
+// All details of the test (message, stack trace and output) which help to find the cause of a failure
+func (tt TraceTest) getDetails() string {
+	var details []string
+	if tt.Message != "" && tt.Message != tt.StackTrace {
+		details = append(details, tt.Message)
+	}
+	if tt.StackTrace != "" {
+		details = append(details, tt.StackTrace)
+	}
+	if tt.Stdout != "" {
+		details = append(details, "Standard output:\n"+tt.Stdout)
+	}
+	if tt.Stderr != "" {
+		details = append(details, "Standard error:\n"+tt.Stderr)
+	}
+	return strings.Join(details, "\n\n")
+}
+
 //	CreateRequirementsMappingReport Creates a requirement mapping file which can be used for processing in a differentinstance of CTM or other tools to process traceability information
 // filepath - the dirpath where to create the file
 // traces - list of all traces from the sourcecode
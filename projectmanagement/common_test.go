@@ -1,9 +1,15 @@
 package projectmanagement
 
 import (
+	"encoding/json"
 	"github.com/SAP/quality-continuous-traceability-monitor/mapping"
+	"github.com/SAP/quality-continuous-traceability-monitor/testreport"
+	"github.com/SAP/quality-continuous-traceability-monitor/utils"
 	"github.com/go-test/deep"
+	"io/ioutil"
+	"os"
 	"testing"
+	"time"
 )
 
 func TestCreateRequirementsMapping(t *testing.T) {
@@ -84,3 +90,250 @@ func TestCreateRequirementsMapping(t *testing.T) {
 	}
 
 }
+
+func TestCreateJSONReportWithFailureDetails(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "ctm-report")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var traces = []Trace{
+		{
+			TraceTests: []TraceTest{
+				{
+					ClassName:  "Class1",
+					MethodName: "FailingTest",
+					Variant:    "[path=\"C:\\tmp\"]",
+					TestResult: testreport.FAILURE,
+					Message:    "expected: \"1\" but was: \"2\"",
+					StackTrace: "AssertionError: expected: \"1\" but was: \"2\"\n\tat Class1.FailingTest(Class1.java:12)",
+					Duration:   1250 * time.Millisecond,
+					Stderr:     "some error output",
+				},
+			},
+			BacklogItem: mapping.BacklogItem{
+				Source: mapping.Jira,
+				ID:     "JIRA-1",
+			},
+		},
+	}
+
+	path := dir + string(os.PathSeparator) + "report.json"
+	CreateJSONReport(path, traces, utils.Config{})
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report map[string]struct {
+		TestCases []map[string]interface{} `json:"test_cases"`
+	}
+	if err := json.Unmarshal(content, &report); err != nil {
+		t.Fatal("JSON report is invalid: ", err, "\n", string(content))
+	}
+
+	testCase := report["JIRA-1"].TestCases[0]
+	if testCase["message"] != traces[0].TraceTests[0].Message || testCase["stack_trace"] != traces[0].TraceTests[0].StackTrace {
+		t.Error("Failure details were not reported: ", testCase)
+	}
+	if testCase["result"] != "failure" {
+		t.Error("Test result was not reported: ", testCase["result"])
+	}
+	if testCase["test_variant"] != traces[0].TraceTests[0].Variant {
+		t.Error("Test variant was not reported: ", testCase["test_variant"])
+	}
+	if testCase["duration_seconds"] != 1.25 || testCase["stderr"] != "some error output" {
+		t.Error("Duration and output were not reported: ", testCase)
+	}
+	if _, found := testCase["stdout"]; found {
+		t.Error("Empty output should not be reported")
+	}
+
+}
+
+func TestEscapeMarkdownTableCell(t *testing.T) {
+
+	escaped := escapeMarkdownTableCell("a | b\n<c>\r\n")
+	if escaped != "a &#124; b<br>&lt;c&gt;" {
+		t.Error("Invalid markdown table cell: ", escaped)
+	}
+
+}
+
+func TestGetTraceResult(t *testing.T) {
+
+	var traces = []Trace{
+		{TraceTests: []TraceTest{{TestResult: testreport.SUCCESS}, {TestResult: testreport.SUCCESS}}},
+		{TraceTests: []TraceTest{{TestResult: testreport.SUCCESS}, {TestResult: testreport.ERROR}}},
+		{TraceTests: []TraceTest{{TestResult: testreport.ERROR}, {TestResult: testreport.FAILURE}}},
+		{TraceTests: []TraceTest{{TestResult: testreport.SKIPPED}, {TestResult: testreport.SUCCESS}}},
+		{TraceTests: nil},
+	}
+
+	var expected = []int{testreport.SUCCESS, testreport.ERROR, testreport.FAILURE, testreport.SKIPPED}
+	for i, result := range expected {
+		if actual, tested := GetTraceResult(traces[i], utils.Config{}); !tested || actual != result {
+			t.Errorf("Trace %d should have result %s, got %s", i, GetResultName(result), GetResultName(actual))
+		}
+	}
+	if _, tested := GetTraceResult(traces[4], utils.Config{}); tested {
+		t.Error("Trace without tests should not be tested")
+	}
+
+	if GetNumberOfSuccessfulTestedTraces(traces, utils.Config{}) != 1 || GetNumberOfTracesWithResult(traces, testreport.ERROR, utils.Config{}) != 1 ||
+		GetNumberOfTracesWithResult(traces, testreport.FAILURE, utils.Config{}) != 1 || GetNumberOfTracesWithResult(traces, testreport.SKIPPED, utils.Config{}) != 1 {
+		t.Error("Invalid number of requirements per result")
+	}
+
+}
+
+func TestGetNumberOfSuccessfulTestedTracesWithFlakyTests(t *testing.T) {
+
+	var traces = []Trace{
+		{TraceTests: []TraceTest{{TestResult: testreport.SUCCESS}, {TestResult: testreport.FLAKY}}},
+		{TraceTests: []TraceTest{{TestResult: testreport.SKIPPED}, {TestResult: testreport.FLAKY}}},
+		{TraceTests: []TraceTest{{TestResult: testreport.SUCCESS}}},
+	}
+
+	if result, _ := GetTraceResult(traces[0], utils.Config{}); result != testreport.FLAKY {
+		t.Error("Requirement with flaky and passed tests should be flaky, got ", GetResultName(result))
+	}
+	if result, _ := GetTraceResult(traces[1], utils.Config{}); result != testreport.SKIPPED {
+		t.Error("Requirement with skipped tests should not be flaky, got ", GetResultName(result))
+	}
+
+	var cfg utils.Config
+	if GetNumberOfSuccessfulTestedTraces(traces, cfg) != 1 {
+		t.Error("Flaky requirements should not count as successful by default")
+	}
+	cfg.TestResults.FlakyAsPassed = true
+	if GetNumberOfSuccessfulTestedTraces(traces, cfg) != 2 {
+		t.Error("Flaky requirements should count as successful if configured")
+	}
+
+}
+
+func TestGetTraceResultWithEnvironments(t *testing.T) {
+
+	var trace = Trace{TraceTests: []TraceTest{
+		{ClassName: "Class1", TestResult: testreport.SUCCESS, Environment: "linux-jdk17"},
+		{ClassName: "Class2", TestResult: testreport.SUCCESS, Environment: "linux-jdk17"},
+		{ClassName: "Class1", TestResult: testreport.FAILURE, Environment: "windows-jdk11"},
+		{ClassName: "Class2", TestResult: testreport.SKIPPED, Environment: "windows-jdk11"},
+	}}
+
+	environmentResults := GetEnvironmentResults(trace)
+	if len(environmentResults) != 2 || environmentResults[0] != (EnvironmentResult{"linux-jdk17", testreport.SUCCESS}) ||
+		environmentResults[1] != (EnvironmentResult{"windows-jdk11", testreport.FAILURE}) {
+		t.Error("Invalid results per environment: ", environmentResults)
+	}
+
+	var cfg utils.Config
+	if result, _ := GetTraceResult(trace, cfg); result != testreport.FAILURE {
+		t.Error("Requirement should fail by default, if it fails in one environment, got ", GetResultName(result))
+	}
+	cfg.TestResults.EnvironmentPolicy = utils.EnvironmentPolicyAll
+	if result, _ := GetTraceResult(trace, cfg); result != testreport.FAILURE {
+		t.Error("Requirement should fail, if it fails in one environment, got ", GetResultName(result))
+	}
+	cfg.TestResults.EnvironmentPolicy = utils.EnvironmentPolicyAny
+	if result, _ := GetTraceResult(trace, cfg); result != testreport.SUCCESS {
+		t.Error("Requirement should pass, if it passes in one environment, got ", GetResultName(result))
+	}
+	if GetNumberOfSuccessfulTestedTraces([]Trace{trace}, cfg) != 1 {
+		t.Error("Requirement should count as successful, if it passes in one environment")
+	}
+
+}
+
+func TestCreateJSONReportWithEnvironments(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "ctm-report")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var traces = []Trace{
+		{
+			TraceTests: []TraceTest{
+				{ClassName: "Class1", MethodName: "Test", TestResult: testreport.SUCCESS, Environment: "linux-jdk17"},
+				{ClassName: "Class1", MethodName: "Test", TestResult: testreport.ERROR, Environment: "windows \"jdk11\""},
+			},
+			BacklogItem: mapping.BacklogItem{Source: mapping.Jira, ID: "JIRA-1"},
+		},
+	}
+
+	path := dir + string(os.PathSeparator) + "report.json"
+	CreateJSONReport(path, traces, utils.Config{})
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report map[string]struct {
+		Result       string                   `json:"result"`
+		Environments map[string]string        `json:"environments"`
+		TestCases    []map[string]interface{} `json:"test_cases"`
+	}
+	if err := json.Unmarshal(content, &report); err != nil {
+		t.Fatal("JSON report is invalid: ", err, "\n", string(content))
+	}
+
+	requirement := report["JIRA-1"]
+	if requirement.Result != "error" || requirement.Environments["linux-jdk17"] != "success" || requirement.Environments["windows \"jdk11\""] != "error" {
+		t.Error("Invalid results per environment were reported: ", requirement.Result, ", ", requirement.Environments)
+	}
+	if requirement.TestCases[0]["test_environment"] != "linux-jdk17" {
+		t.Error("Test environment was not reported: ", requirement.TestCases[0])
+	}
+
+}
+
+func TestCreateJSONReportWithAttempts(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "ctm-report")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var traces = []Trace{
+		{
+			TraceTests: []TraceTest{
+				{ClassName: "Class1", MethodName: "Test", ReportFile: "retry/TEST-Class1.xml", TestResult: testreport.FLAKY,
+					Attempts: []TestAttempt{{ReportFile: "shard1/TEST-Class1.xml", TestResult: testreport.FAILURE, Timestamp: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC), Message: "timeout"}}},
+			},
+			BacklogItem: mapping.BacklogItem{Source: mapping.Jira, ID: "JIRA-1"},
+		},
+	}
+
+	path := dir + string(os.PathSeparator) + "report.json"
+	CreateJSONReport(path, traces, utils.Config{})
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report map[string]struct {
+		TestCases []struct {
+			Result             string              `json:"result"`
+			SupersededAttempts []map[string]string `json:"superseded_attempts"`
+		} `json:"test_cases"`
+	}
+	if err := json.Unmarshal(content, &report); err != nil {
+		t.Fatal("JSON report is invalid: ", err, "\n", string(content))
+	}
+
+	testCase := report["JIRA-1"].TestCases[0]
+	if testCase.Result != "flaky" || len(testCase.SupersededAttempts) != 1 {
+		t.Fatal("Superseded attempts were not reported: ", string(content))
+	}
+	attempt := testCase.SupersededAttempts[0]
+	if attempt["report_file"] != "shard1/TEST-Class1.xml" || attempt["result"] != "failure" || attempt["timestamp"] != "2023-01-01T10:00:00Z" || attempt["message"] != "timeout" {
+		t.Error("Invalid superseded attempt was reported: ", attempt)
+	}
+
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/golang/glog"
 	"github.com/google/go-github/github"
 	"golang.org/x/oauth2"
+	"html"
 	"io/ioutil"
 	"net/url"
 	"os"
@@ -150,14 +151,18 @@ func createReadme(path string, traces []Trace, verbose bool, cfg utils.Config) *
 			testResult = ":heavy_exclamation_mark:"
 			testClass = "Missing"
 		} else {
-			for _, tt := range trace.TraceTests {
-				// If a delivery version is set and we have a GitHub access token, than we can create GitHub releases
-				branch := GetGHBranch(cfg)
-				if tt.TestResult == testreport.SUCCESS && !strings.Contains(testResult, ":x:") { // One failing test, fails the complete backlog item
-					testResult = "[:heavy_check_mark:](" + GetTestResultURL(cfg, trace.BacklogItem, branch) + ")"
-				} else if tt.TestResult == testreport.FAILURE {
-					testResult = "[:x:](" + GetTestResultURL(cfg, trace.BacklogItem, branch) + ")"
+			// If a delivery version is set and we have a GitHub access token, than we can create GitHub releases
+			branch := GetGHBranch(cfg)
+			traceResult, _ := GetTraceResult(trace, cfg) // One failing test, fails the complete backlog item (unless it passed in another environment and any environment may pass)
+			testResult = "[" + getMarkdownResult(traceResult) + "](" + GetTestResultURL(cfg, trace.BacklogItem, branch) + ")"
+			if hasEnvironments(trace) {
+				var environments []string
+				for _, environmentResult := range GetEnvironmentResults(trace) {
+					environments = append(environments, escapeMarkdownTableCell(getEnvironmentName(environmentResult.Environment))+" "+getMarkdownResult(environmentResult.Result))
 				}
+				testResult = testResult + " (" + strings.Join(environments, ", ") + ")"
+			}
+			for _, tt := range trace.TraceTests {
 				if verbose {
 					var classAndMethod string
 					if tt.MethodName != "" {
@@ -165,17 +170,24 @@ func createReadme(path string, traces []Trace, verbose bool, cfg utils.Config) *
 					} else {
 						classAndMethod = tt.ClassName
 					}
+					if variant := tt.getVariant(); variant != "" {
+						classAndMethod = classAndMethod + " [" + variant + "]"
+					}
 					if tt.SourceFile != "" {
 						testClass = testClass + " * [" + classAndMethod + "](" + tt.SourceFile + ") => "
 					} else {
 						testClass = testClass + " * " + classAndMethod + " => "
 					}
 
-					if tt.TestResult == testreport.SUCCESS {
-						testClass = testClass + ":heavy_check_mark:<br>"
-					} else if tt.TestResult == testreport.FAILURE {
-						testClass = testClass + ":x:<br>"
+					testClass = testClass + getMarkdownResult(tt.TestResult)
+					if tt.Duration > 0 {
+						testClass = testClass + " (" + tt.getDuration() + ")"
+					}
+					// Show the cause of a failure right away (collapsed, as stack traces and output can be long)
+					if details := tt.getDetails(); details != "" && tt.TestResult != testreport.SUCCESS {
+						testClass = testClass + "<details><summary>" + escapeMarkdownTableCell(tt.getSummary()) + "</summary>" + escapeMarkdownTableCell(details) + "</details>"
 					}
+					testClass = testClass + "<br>"
 				}
 			}
 		}
@@ -190,6 +202,10 @@ func createReadme(path string, traces []Trace, verbose bool, cfg utils.Config) *
 	f.WriteString("  \n")
 	f.WriteString("  \n")
 
+	f.WriteString(getMarkdownResult(testreport.SUCCESS) + " passed &nbsp; " + getMarkdownResult(testreport.FAILURE) + " failed &nbsp; " +
+		getMarkdownResult(testreport.ERROR) + " error &nbsp; " + getMarkdownResult(testreport.SKIPPED) + " skipped &nbsp; " + getMarkdownResult(testreport.FLAKY) + " flaky  \n")
+	f.WriteString("  \n")
+
 	if traces == nil {
 		f.WriteString("### No issues traced to automated tests yet.")
 
@@ -209,6 +225,8 @@ func createReadme(path string, traces []Trace, verbose bool, cfg utils.Config) *
 
 func createBacklogFolder(tmpRepoPath string, traces []Trace, cfg utils.Config) {
 
+	defer testreport.CloseArchives()
+
 	for _, trace := range traces {
 
 		// Create backlog item path (if it doesn't exist)
@@ -222,8 +240,17 @@ func createBacklogFolder(tmpRepoPath string, traces []Trace, cfg utils.Config) {
 		os.MkdirAll(biPath, os.FileMode(0755))
 
 		for _, test := range trace.TraceTests {
+			// Test reports might be located within archives, so read them (instead of copying the file)
+			content, err := testreport.ReadReportFile(test.ReportFile)
+			if err != nil {
+				glog.Error("Unable to read test report ", test.ReportFile, ": ", err)
+				continue
+			}
 			var rfPath = biPath + string(os.PathSeparator) + path.Base(test.ReportFile)
-			Copy(test.ReportFile, rfPath)
+			err = ioutil.WriteFile(rfPath, content, os.FileMode(0644))
+			if err != nil {
+				glog.Error("Unable to write test report ", rfPath, ": ", err)
+			}
 		}
 
 		createReadme(biPath, []Trace{trace}, true, cfg)
@@ -310,3 +337,27 @@ func createRelease(client *GitHubClient, file *os.File) {
 	}
 
 }
+
+// Markdown table cells have to be on a single line and must not contain a pipe (which separates the cells)
+func escapeMarkdownTableCell(text string) string {
+	text = html.EscapeString(strings.TrimSpace(text))
+	text = strings.Replace(text, "|", "&#124;", -1)
+	text = strings.Replace(text, "\r", "", -1)
+	return strings.Replace(text, "\n", "<br>", -1)
+}
+
+// Emoji representing a test result in the markdown README
+func getMarkdownResult(result int) string {
+	switch result {
+	case testreport.SUCCESS:
+		return ":heavy_check_mark:"
+	case testreport.ERROR:
+		return ":boom:"
+	case testreport.SKIPPED:
+		return ":fast_forward:"
+	case testreport.FLAKY:
+		return ":warning:"
+	default:
+		return ":x:"
+	}
+}
@@ -0,0 +1,155 @@
+package testreport
+
+import (
+	"time"
+
+	"github.com/SAP/quality-continuous-traceability-monitor/utils"
+	"github.com/golang/glog"
+)
+
+// testCaseKey identifies the same test case in different test reports
+type testCaseKey struct {
+	ClassName, MethodName, Variant, Environment string
+}
+
+// runKey identifies the same run of a test case in different test reports. A test case might run multiple times within
+// one report file (e.g. parameterized tests), so its n-th run in one report file corresponds to its n-th run in another
+type runKey struct {
+	testCase   testCaseKey
+	occurrence int
+}
+
+// groupTestCaseRuns groups the test cases by runKey. The keys are returned in the order the runs were found
+func groupTestCaseRuns(testSuites []TestSuite) (map[runKey][]*TestCase, []runKey) {
+	type fileKey struct {
+		testCase       testCaseKey
+		reportFileName string
+	}
+	var testCases = make(map[runKey][]*TestCase)
+	var keys []runKey
+	var occurrences = make(map[fileKey]int)
+	for _, ts := range testSuites {
+		for _, tc := range ts.TestCase {
+			key := testCaseKey{tc.ClassName, tc.MethodName, tc.Variant, tc.Environment}
+			run := runKey{key, occurrences[fileKey{key, tc.ReportFileName}]}
+			occurrences[fileKey{key, tc.ReportFileName}]++
+			if _, found := testCases[run]; !found {
+				keys = append(keys, run)
+			}
+			testCases[run] = append(testCases[run], tc)
+		}
+	}
+	return testCases, keys
+}
+
+// AggregateTestCases merges the runs of the very same test case (class, method, variant and environment) in multiple
+// test report files (e.g. of sharded and retried CI jobs) into one test case. The merge strategy decides on the result:
+//   - utils.MergeStrategyLatest: The result of the latest run (see TestCase.Timestamp)
+//   - utils.MergeStrategyAnyPass: The test passed, if it passed in any run (the least severe result)
+//   - utils.MergeStrategyAllPass: The test passed, if it passed in all runs (the most severe result)
+//
+// A test which passed in the latest run, but failed in another one is flaky. The superseded runs are kept in
+// TestCase.Attempts. Runs of the same test case in the same report file (e.g. parameterized tests) aren't merged with
+// each other, instead the n-th run in one report file is merged with the n-th run in the other report files. With
+// utils.MergeStrategyNone the test cases aren't merged, but marked as flaky in case of mixed outcomes (see
+// MarkFlakyTestCases)
+func AggregateTestCases(testSuites []TestSuite, strategy string) []TestSuite {
+	defer utils.TimeTrack(time.Now(), "Aggregate test results")
+
+	if strategy == utils.MergeStrategyNone {
+		MarkFlakyTestCases(testSuites)
+		return testSuites
+	}
+
+	testCases, _ := groupTestCaseRuns(testSuites)
+
+	// The merged test case takes the place of the first run of the test case, all other runs are removed
+	var merged = make(map[*TestCase]*TestCase)
+	var superseded = make(map[*TestCase]bool)
+	for _, runs := range testCases {
+		if !inMultipleReportFiles(runs) {
+			continue
+		}
+		merged[runs[0]] = mergeTestCases(runs, strategy)
+		for _, run := range runs[1:] {
+			superseded[run] = true
+		}
+	}
+	if len(merged) == 0 {
+		return testSuites
+	}
+
+	var aggregated []TestSuite
+	for _, ts := range testSuites {
+		var suiteTestCases []*TestCase
+		for _, tc := range ts.TestCase {
+			if superseded[tc] {
+				continue
+			}
+			if mergedTestCase, found := merged[tc]; found {
+				tc = mergedTestCase
+			}
+			suiteTestCases = append(suiteTestCases, tc)
+		}
+		if suiteTestCases != nil {
+			aggregated = append(aggregated, TestSuite{ts.Name, suiteTestCases})
+		}
+	}
+	glog.Info("Merged the runs of ", len(merged), " test cases, which are part of multiple test reports")
+
+	return aggregated
+}
+
+func inMultipleReportFiles(testCases []*TestCase) bool {
+	for _, tc := range testCases[1:] {
+		if tc.ReportFileName != testCases[0].ReportFileName {
+			return true
+		}
+	}
+	return false
+}
+
+// Merge the runs of a test case according to the merge strategy. The run deciding on the result is taken over, all
+// other runs are kept as (superseded) attempts
+func mergeTestCases(runs []*TestCase, strategy string) *TestCase {
+	var decisive = runs[0]
+	for _, run := range runs[1:] {
+		switch strategy {
+		case utils.MergeStrategyAnyPass:
+			if GetSeverity(run.Result) > GetSeverity(decisive.Result) {
+				decisive = run
+			}
+		case utils.MergeStrategyAllPass:
+			if GetSeverity(run.Result) < GetSeverity(decisive.Result) {
+				decisive = run
+			}
+		default: // utils.MergeStrategyLatest
+			if !run.Timestamp.Before(decisive.Timestamp) {
+				decisive = run
+			}
+		}
+	}
+
+	var mergedTestCase = *decisive
+	mergedTestCase.Attempts = nil
+	for _, run := range runs {
+		if run != decisive {
+			mergedTestCase.Attempts = append(mergedTestCase.Attempts, run)
+		}
+	}
+
+	// Passed, but failed in another run. With utils.MergeStrategyAnyPass that's still a pass
+	if mergedTestCase.Result == SUCCESS && strategy != utils.MergeStrategyAnyPass && isFlaky(runs) {
+		mergedTestCase.Result = FLAKY
+		if mergedTestCase.Message == "" {
+			for _, attempt := range mergedTestCase.Attempts {
+				if attempt.Result == FAILURE || attempt.Result == ERROR {
+					mergedTestCase.Message = attempt.Message
+					mergedTestCase.StackTrace = attempt.StackTrace
+					break
+				}
+			}
+		}
+	}
+	return &mergedTestCase
+}
@@ -0,0 +1,110 @@
+package testreport
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SAP/quality-continuous-traceability-monitor/utils"
+)
+
+func getRetriedTestSuites() []TestSuite {
+	first := time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)
+	second := first.Add(time.Hour)
+	return []TestSuite{
+		{Name: "MyTest", TestCase: []*TestCase{
+			{ReportFileName: "shard1/TEST-MyTest.xml", ClassName: "MyTest", MethodName: "unstableTest", Result: FAILURE, Message: "timeout", Timestamp: first},
+			{ReportFileName: "shard1/TEST-MyTest.xml", ClassName: "MyTest", MethodName: "fixedTest", Result: SUCCESS, Timestamp: first},
+			{ReportFileName: "shard1/TEST-MyTest.xml", ClassName: "MyTest", MethodName: "paramTest", Result: SUCCESS, Timestamp: first},
+			{ReportFileName: "shard1/TEST-MyTest.xml", ClassName: "MyTest", MethodName: "paramTest", Result: FAILURE, Timestamp: first},
+		}},
+		{Name: "OtherTest", TestCase: []*TestCase{
+			{ReportFileName: "shard2/TEST-OtherTest.xml", ClassName: "OtherTest", MethodName: "someTest", Result: SUCCESS, Timestamp: first},
+		}},
+		{Name: "MyTest", TestCase: []*TestCase{
+			{ReportFileName: "retry/TEST-MyTest.xml", ClassName: "MyTest", MethodName: "unstableTest", Result: SUCCESS, Timestamp: second},
+			{ReportFileName: "retry/TEST-MyTest.xml", ClassName: "MyTest", MethodName: "fixedTest", Result: FAILURE, Timestamp: second},
+		}},
+	}
+}
+
+func TestAggregateTestCases(t *testing.T) {
+	tests := []struct {
+		strategy                string
+		unstableTest, fixedTest int
+	}{
+		{utils.MergeStrategyLatest, FLAKY, FAILURE},
+		{utils.MergeStrategyAnyPass, SUCCESS, SUCCESS},
+		{utils.MergeStrategyAllPass, FAILURE, FAILURE},
+	}
+
+	for _, test := range tests {
+		ts := AggregateTestCases(getRetriedTestSuites(), test.strategy)
+
+		if len(ts) != 2 {
+			t.Fatal(test.strategy, ": Should keep exactly two test suites, got ", len(ts))
+		}
+		checkTestCases(t, ts[0].TestCase, []expectedTestCase{
+			{"MyTest", "unstableTest", test.unstableTest},
+			{"MyTest", "fixedTest", test.fixedTest},
+			{"MyTest", "paramTest", SUCCESS},
+			{"MyTest", "paramTest", FAILURE},
+		})
+		checkTestCases(t, ts[1].TestCase, []expectedTestCase{{"OtherTest", "someTest", SUCCESS}})
+
+		unstable := ts[0].TestCase[0]
+		if len(unstable.Attempts) != 1 {
+			t.Fatal(test.strategy, ": Superseded run should be kept as attempt")
+		}
+		if test.unstableTest == FLAKY && (unstable.ReportFileName != "retry/TEST-MyTest.xml" || unstable.Message != "timeout" || unstable.Attempts[0].Result != FAILURE) {
+			t.Error(test.strategy, ": Invalid merged test case: ", unstable.ReportFileName, ", ", unstable.Message)
+		}
+	}
+}
+
+func TestAggregateTestCasesNone(t *testing.T) {
+	ts := AggregateTestCases(getRetriedTestSuites(), utils.MergeStrategyNone)
+
+	if len(ts) != 3 || len(ts[0].TestCase) != 4 || len(ts[2].TestCase) != 2 {
+		t.Fatal("Test cases should not be merged")
+	}
+	if ts[0].TestCase[0].Result != FLAKY || ts[2].TestCase[0].Result != FLAKY {
+		t.Error("Test cases with mixed outcomes should be flaky")
+	}
+}
+
+func TestAggregateParameterizedTestCases(t *testing.T) {
+	first := time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)
+	second := first.Add(time.Hour)
+	ts := []TestSuite{
+		{Name: "MyTest", TestCase: []*TestCase{
+			{ReportFileName: "a.xml", ClassName: "MyTest", MethodName: "paramTest", Result: FAILURE, Message: "a failed", Timestamp: first},
+			{ReportFileName: "a.xml", ClassName: "MyTest", MethodName: "paramTest", Result: SUCCESS, Timestamp: first},
+			{ReportFileName: "a.xml", ClassName: "MyTest", MethodName: "otherParamTest", Result: FAILURE, Message: "a failed", Timestamp: first},
+		}},
+		{Name: "MyTest", TestCase: []*TestCase{
+			{ReportFileName: "b.xml", ClassName: "MyTest", MethodName: "paramTest", Result: FAILURE, Message: "b failed", Timestamp: second},
+			{ReportFileName: "b.xml", ClassName: "MyTest", MethodName: "paramTest", Result: SUCCESS, Timestamp: second},
+		}},
+		{Name: "MyTest", TestCase: []*TestCase{
+			{ReportFileName: "c.xml", ClassName: "MyTest", MethodName: "otherParamTest", Result: FAILURE, Message: "c failed", Timestamp: first},
+			{ReportFileName: "d.xml", ClassName: "MyTest", MethodName: "otherParamTest", Result: SUCCESS, Timestamp: second},
+		}},
+	}
+
+	ts = AggregateTestCases(ts, utils.MergeStrategyLatest)
+
+	if len(ts) != 1 {
+		t.Fatal("Should keep exactly one test suite, got ", len(ts))
+	}
+	checkTestCases(t, ts[0].TestCase, []expectedTestCase{
+		{"MyTest", "paramTest", FAILURE},
+		{"MyTest", "paramTest", SUCCESS},
+		{"MyTest", "otherParamTest", FLAKY},
+	})
+	if ts[0].TestCase[0].Message != "b failed" || len(ts[0].TestCase[0].Attempts) != 1 || len(ts[0].TestCase[1].Attempts) != 1 {
+		t.Error("Only the runs with the same position in the report files should be merged")
+	}
+	if ts[0].TestCase[2].Message != "a failed" || len(ts[0].TestCase[2].Attempts) != 2 {
+		t.Error("Flaky test case should take the message of the first failed run: ", ts[0].TestCase[2].Message)
+	}
+}
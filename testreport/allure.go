@@ -0,0 +1,288 @@
+package testreport
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SAP/quality-continuous-traceability-monitor/utils"
+	"github.com/golang/glog"
+)
+
+// Link to a GitHub issue (e.g. https://github.com/myOrg/myRepo/issues/5)
+var reGitHubIssueURL = regexp.MustCompile(`/([^/]+)/([^/]+)/issues/([0-9]+)/?$`)
+
+// Allure label, link and tag values which already reference a backlog item (e.g. Jira:ABC-1)
+var reBacklogItemTag = regexp.MustCompile(`(?i)^(github|jira):`)
+
+// AllureLabel Allure label struct
+type AllureLabel struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// AllureLink Allure link struct
+type AllureLink struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	Type string `json:"type"`
+}
+
+// AllureStatusDetails Allure status details struct
+type AllureStatusDetails struct {
+	Message string `json:"message,omitempty"`
+	Trace   string `json:"trace,omitempty"`
+	Flaky   bool   `json:"flaky,omitempty"`
+}
+
+// AllureResult Allure test result (<uuid>-result.json) structure
+type AllureResult struct {
+	UUID          string               `json:"uuid"`
+	HistoryID     string               `json:"historyId,omitempty"`
+	FullName      string               `json:"fullName"`
+	Name          string               `json:"name"`
+	Status        string               `json:"status"`
+	StatusDetails *AllureStatusDetails `json:"statusDetails,omitempty"`
+	Stage         string               `json:"stage,omitempty"`
+	Start         int64                `json:"start,omitempty"`
+	Stop          int64                `json:"stop,omitempty"`
+	Labels        []*AllureLabel       `json:"labels,omitempty"`
+	Links         []*AllureLink        `json:"links,omitempty"`
+	reportFile    string               // File the result was read from
+}
+
+// AllureFixture Allure fixture (before/after) structure
+type AllureFixture struct {
+	Name          string               `json:"name"`
+	Status        string               `json:"status"`
+	StatusDetails *AllureStatusDetails `json:"statusDetails,omitempty"`
+}
+
+// AllureContainer Allure test result container (<uuid>-container.json) structure
+type AllureContainer struct {
+	UUID     string           `json:"uuid"`
+	Name     string           `json:"name,omitempty"`
+	Children []string         `json:"children,omitempty"`
+	Befores  []*AllureFixture `json:"befores,omitempty"`
+	Afters   []*AllureFixture `json:"afters,omitempty"`
+}
+
+// AllureTestReport Allure results (allure-results directory) test report structure
+type AllureTestReport struct {
+}
+
+// Parse an Allure results directory (allure-results)
+func (atr *AllureTestReport) Parse(reportRootPath string) []TestSuite {
+	defer utils.TimeTrack(time.Now(), "Scan Allure results")
+
+	var collector allureCollector
+	parseReportFiles(reportRootPath, isAllureFile, readContent(func(jsonFilePath string, jfb []byte, ts []TestSuite) []TestSuite {
+		collector.collect(jsonFilePath, jfb)
+		return ts
+	}))
+
+	var ts = []TestSuite{}
+	return collector.addToTestResult(ts)
+}
+
+func isAllureFile(path string) bool {
+	return strings.HasSuffix(path, "-result.json") || strings.HasSuffix(path, "-container.json")
+}
+
+// Allure results can only be mapped after all result containers are known. Therefore the results and containers
+// of all files are collected first
+func collectAllureFile(jsonFilePath string, jfb []byte, results []*AllureResult, containers []*AllureContainer) ([]*AllureResult, []*AllureContainer) {
+	if strings.HasSuffix(jsonFilePath, "-container.json") {
+		var container AllureContainer
+		if err := json.Unmarshal(jfb, &container); err != nil {
+			glog.Error("Unable to parse Allure container file ", jsonFilePath, ": ", err)
+			return results, containers
+		}
+		return results, append(containers, &container)
+	}
+
+	result := parseAllureResult(jsonFilePath, jfb)
+	if result != nil {
+		results = append(results, result)
+	}
+	return results, containers
+}
+
+// allureFile results and containers of a single Allure file
+type allureFile struct {
+	results    []*AllureResult
+	containers []*AllureContainer
+}
+
+// allureCollector collects the results and containers of all Allure files. As the files are parsed concurrently,
+// they are ordered by their path before they are mapped to test suites
+type allureCollector struct {
+	mutex sync.Mutex
+	files map[string]allureFile
+}
+
+func (ac *allureCollector) collect(jsonFilePath string, jfb []byte) {
+	results, containers := collectAllureFile(jsonFilePath, jfb, nil, nil)
+
+	ac.mutex.Lock()
+	defer ac.mutex.Unlock()
+	if ac.files == nil {
+		ac.files = make(map[string]allureFile)
+	}
+	ac.files[jsonFilePath] = allureFile{results, containers}
+}
+
+func (ac *allureCollector) addToTestResult(ts []TestSuite) []TestSuite {
+	var paths []string
+	for path := range ac.files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var results []*AllureResult
+	var containers []*AllureContainer
+	for _, path := range paths {
+		results = append(results, ac.files[path].results...)
+		containers = append(containers, ac.files[path].containers...)
+	}
+	return addAllureResultsToTestResult(ts, results, containers)
+}
+
+func parseAllureResult(jsonFilePath string, jfb []byte) *AllureResult {
+	var result AllureResult
+	err := json.Unmarshal(jfb, &result)
+	if err != nil || result.UUID == "" {
+		glog.Info("Not an Allure result file ", jsonFilePath, ": ", err)
+		return nil
+	}
+	result.reportFile = jsonFilePath
+	return &result
+}
+
+// Map the Allure results to the common (generalized) TestSuite struct. One TestSuite per test class is created.
+// A failing before fixture (of a result container) is reported as error for all its child results
+func addAllureResultsToTestResult(ts []TestSuite, results []*AllureResult, containers []*AllureContainer) []TestSuite {
+	var brokenSetup = make(map[string]bool)
+	for _, container := range containers {
+		for _, before := range container.Befores {
+			if before.Status == "failed" || before.Status == "broken" {
+				for _, child := range container.Children {
+					brokenSetup[child] = true
+				}
+				break
+			}
+		}
+	}
+
+	var suites []TestSuite
+	var suiteIndex = make(map[string]int)
+	for _, result := range results {
+		className, methodName := result.getClassAndMethodName()
+		testcase := &TestCase{ReportFileName: result.reportFile, ClassName: className, MethodName: methodName, Result: getAllureResult(result.Status), Tags: result.getTags()}
+		if result.Start > 0 { // Milliseconds since epoch
+			testcase.Timestamp = time.Unix(0, result.Start*int64(time.Millisecond))
+		}
+		if result.Stop > result.Start {
+			testcase.Duration = time.Duration(result.Stop-result.Start) * time.Millisecond
+		}
+		if result.StatusDetails != nil {
+			testcase.Message = result.StatusDetails.Message
+			testcase.StackTrace = strings.TrimSpace(result.StatusDetails.Trace)
+		}
+		if brokenSetup[result.UUID] {
+			testcase.Result = ERROR
+		}
+
+		i, found := suiteIndex[className]
+		if !found {
+			i = len(suites)
+			suiteIndex[className] = i
+			suites = append(suites, TestSuite{Name: className})
+		}
+		suites[i].TestCase = append(suites[i].TestCase, testcase)
+	}
+
+	return append(ts, suites...)
+}
+
+func (ar *AllureResult) getLabel(name string) string {
+	for _, label := range ar.Labels {
+		if label.Name == name {
+			return label.Value
+		}
+	}
+	return ""
+}
+
+// Class and method name are taken from the testClass and testMethod labels (if available). Otherwise they are
+// derived from the full name (e.g. com.myCompany.MyTest.myMethod or com.myCompany.MyTest#myMethod)
+func (ar *AllureResult) getClassAndMethodName() (string, string) {
+	className := ar.getLabel("testClass")
+	methodName := ar.getLabel("testMethod")
+	if methodName == "" {
+		methodName = ar.Name
+	}
+	if className != "" {
+		return className, methodName
+	}
+
+	if i := strings.LastIndex(ar.FullName, "#"); i != -1 {
+		return ar.FullName[:i], ar.FullName[i+1:]
+	}
+	if strings.HasSuffix(ar.FullName, "."+methodName) {
+		return strings.TrimSuffix(ar.FullName, "."+methodName), methodName
+	}
+	if suite := ar.getLabel("suite"); suite != "" {
+		return suite, methodName
+	}
+	return ar.FullName, methodName
+}
+
+// Collect the tags of an Allure result. Links of type issue/tms and jira/github labels are converted into
+// backlog item references (e.g. Jira:ABC-1 or GitHub:myOrg/myRepo#5)
+func (ar *AllureResult) getTags() []string {
+	var tags []string
+	for _, label := range ar.Labels {
+		switch strings.ToLower(label.Name) {
+		case "jira":
+			tags = append(tags, "Jira:"+label.Value)
+		case "github":
+			tags = append(tags, "GitHub:"+label.Value)
+		case "tag", "issue", "tms":
+			tags = append(tags, label.Value)
+		}
+	}
+	for _, link := range ar.Links {
+		if link.Type != "issue" && link.Type != "tms" {
+			continue
+		}
+		if reBacklogItemTag.MatchString(link.Name) {
+			tags = append(tags, link.Name)
+		} else if m := reGitHubIssueURL.FindStringSubmatch(link.URL); m != nil && strings.Contains(link.URL, "github") {
+			tags = append(tags, "GitHub:"+m[1]+"/"+m[2]+"#"+m[3])
+		} else if strings.Contains(link.URL, "/browse/") {
+			tags = append(tags, "Jira:"+link.URL[strings.LastIndex(link.URL, "/browse/")+8:])
+		} else {
+			tags = append(tags, link.Name)
+		}
+	}
+	return tags
+}
+
+// Map Allure test status (passed, failed, broken, skipped, unknown) to our test result. Allure reports tests which
+// failed because of an unexpected exception (instead of a failed assertion) as broken
+func getAllureResult(status string) int {
+	switch status {
+	case "passed":
+		return SUCCESS
+	case "skipped", "unknown":
+		return SKIPPED
+	case "broken":
+		return ERROR
+	default:
+		return FAILURE
+	}
+}
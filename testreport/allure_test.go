@@ -0,0 +1,103 @@
+package testreport
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+var testAllureResults = []string{
+	`{
+		"uuid": "6b1d6a0e-0001",
+		"historyId": "a1",
+		"fullName": "com.sap.ctm.testing.LoginTest.successfulLogin",
+		"name": "successfulLogin",
+		"status": "passed",
+		"start": 1572949570100,
+		"stop": 1572949570300,
+		"labels": [
+			{"name": "package", "value": "com.sap.ctm.testing"},
+			{"name": "testClass", "value": "com.sap.ctm.testing.LoginTest"},
+			{"name": "testMethod", "value": "successfulLogin"},
+			{"name": "jira", "value": "MYJIRAPROJECT-1"}
+		],
+		"links": [
+			{"name": "MYJIRAPROJECT-2", "url": "https://jira.my.corp/browse/MYJIRAPROJECT-2", "type": "issue"},
+			{"name": "5", "url": "https://github.com/myOrg/myRepo/issues/5", "type": "tms"},
+			{"name": "Docs", "url": "https://docs.my.corp/login", "type": "link"}
+		]
+	}`,
+	`{
+		"uuid": "6b1d6a0e-0002",
+		"fullName": "com.sap.ctm.testing.LoginTest#failedLogin",
+		"name": "failedLogin",
+		"status": "broken",
+		"statusDetails": {"message": "NullPointerException"}
+	}`,
+	`{
+		"uuid": "6b1d6a0e-0003",
+		"fullName": "com.sap.ctm.testing.LogoutTest.logout",
+		"name": "logout",
+		"status": "skipped",
+		"labels": [{"name": "tag", "value": "GitHub:myOrg/myRepo#6"}]
+	}`,
+	`{
+		"uuid": "6b1d6a0e-0004",
+		"fullName": "com.sap.ctm.testing.LogoutTest.logoutTwice",
+		"name": "logoutTwice",
+		"status": "passed"
+	}`,
+}
+
+func TestParseAllureResults(t *testing.T) {
+	var results []*AllureResult
+	for i, r := range testAllureResults {
+		result := parseAllureResult("allure-results/"+strings.Repeat("x", i)+"-result.json", []byte(r))
+		if result == nil {
+			t.Fatal("Unable to parse Allure result ", i)
+		}
+		results = append(results, result)
+	}
+	containers := []*AllureContainer{
+		{UUID: "c1", Children: []string{"6b1d6a0e-0004"}, Befores: []*AllureFixture{{Name: "setUp", Status: "broken"}}},
+	}
+
+	var ts = []TestSuite{}
+	ts = addAllureResultsToTestResult(ts, results, containers)
+
+	if len(ts) != 2 {
+		t.Fatal("Should parse exactly two test suites (one per test class)")
+	}
+	checkTestCases(t, ts[0].TestCase, []expectedTestCase{
+		{"com.sap.ctm.testing.LoginTest", "successfulLogin", SUCCESS},
+		{"com.sap.ctm.testing.LoginTest", "failedLogin", ERROR},
+	})
+	checkTestCases(t, ts[1].TestCase, []expectedTestCase{
+		{"com.sap.ctm.testing.LogoutTest", "logout", SKIPPED},
+		{"com.sap.ctm.testing.LogoutTest", "logoutTwice", ERROR},
+	})
+
+	if ts[0].TestCase[0].ReportFileName != "allure-results/-result.json" {
+		t.Error("Invalid report file name: ", ts[0].TestCase[0].ReportFileName)
+	}
+	if timestamp := ts[0].TestCase[0].Timestamp; !timestamp.Equal(time.Date(2019, 11, 5, 10, 26, 10, 100000000, time.UTC)) {
+		t.Error("Invalid start time was parsed: ", timestamp)
+	}
+	if duration := ts[0].TestCase[0].Duration; duration != 200*time.Millisecond {
+		t.Error("Invalid duration was parsed: ", duration)
+	}
+
+	expectedTags := "Jira:MYJIRAPROJECT-1,Jira:MYJIRAPROJECT-2,GitHub:myOrg/myRepo#5"
+	if tags := strings.Join(ts[0].TestCase[0].Tags, ","); tags != expectedTags {
+		t.Errorf("Invalid tags were parsed.\nExpected: %s\nActual: %s", expectedTags, tags)
+	}
+	if tags := strings.Join(ts[1].TestCase[0].Tags, ","); tags != "GitHub:myOrg/myRepo#6" {
+		t.Error("Invalid tags were parsed: ", tags)
+	}
+}
+
+func TestParseAllureResultIgnoresOtherJSON(t *testing.T) {
+	if parseAllureResult("package-result.json", []byte(`{"name": "my-app", "version": "1.0.0"}`)) != nil {
+		t.Error("Should not parse an Allure result from a non Allure JSON file")
+	}
+}
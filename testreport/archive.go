@@ -0,0 +1,230 @@
+package testreport
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ArchivePathSeparator separates the path of an archive and the path of a test report within the archive
+// (e.g. target/test-results.zip!/surefire-reports/TEST-MyTest.xml)
+const ArchivePathSeparator = "!/"
+
+// isArchive checks whether the given file is a (supported) archive, i.e. a .zip or .tar.gz file
+func isArchive(path string) bool {
+	lowerPath := strings.ToLower(path)
+	return strings.HasSuffix(lowerPath, ".zip") || strings.HasSuffix(lowerPath, ".tar.gz") || strings.HasSuffix(lowerPath, ".tgz")
+}
+
+// readArchive passes all files within the archive accepted by match to readEntry. The content of a file can only be
+// read while readEntry runs
+func readArchive(archivePath string, match func(path string) bool, readEntry func(entryPath string, content io.Reader, modTime time.Time)) error {
+	if strings.HasSuffix(strings.ToLower(archivePath), ".zip") {
+		return readZipArchive(archivePath, match, readEntry)
+	}
+	return readTarGzArchive(archivePath, match, readEntry)
+}
+
+func readZipArchive(archivePath string, match func(path string) bool, readEntry func(entryPath string, content io.Reader, modTime time.Time)) error {
+	zipReader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer zipReader.Close()
+
+	for _, entry := range zipReader.File {
+		if entry.FileInfo().IsDir() || !match(entry.Name) {
+			continue
+		}
+		entryReader, err := entry.Open()
+		if err != nil {
+			return err
+		}
+		readEntry(entry.Name, entryReader, entry.Modified)
+		entryReader.Close()
+	}
+	return nil
+}
+
+func readTarGzArchive(archivePath string, match func(path string) bool, readEntry func(entryPath string, content io.Reader, modTime time.Time)) error {
+	archiveFile, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer archiveFile.Close()
+
+	gzipReader, err := gzip.NewReader(archiveFile)
+	if err != nil {
+		return err
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		entryPath := strings.TrimPrefix(header.Name, "./")
+		if header.Typeflag != tar.TypeReg || !match(entryPath) {
+			continue
+		}
+		readEntry(entryPath, tarReader, header.ModTime)
+	}
+}
+
+// Archives read by ReadReportFile (by archive path). Each archive is opened only once per run
+var archiveCache = struct {
+	sync.Mutex
+	archives map[string]*cachedArchive
+}{archives: make(map[string]*cachedArchive)}
+
+// cachedArchive gives access to the files within an archive without reading the archive again for every file. Zip
+// archives are indexed, so an entry is read directly. Tar.gz archives can only be read sequentially. They are read up to
+// the requested entry and the test reports passed on the way are kept in memory, so the next request continues where
+// the previous one stopped
+type cachedArchive struct {
+	zipReader  *zip.ReadCloser
+	zipEntries map[string]*zip.File
+	tarFile    *os.File
+	tarReader  *tar.Reader
+	tarEntries map[string][]byte
+}
+
+func openCachedArchive(archivePath string) (*cachedArchive, error) {
+	if strings.HasSuffix(strings.ToLower(archivePath), ".zip") {
+		zipReader, err := zip.OpenReader(archivePath)
+		if err != nil {
+			return nil, err
+		}
+		archive := &cachedArchive{zipReader: zipReader, zipEntries: make(map[string]*zip.File)}
+		for _, entry := range zipReader.File {
+			if !entry.FileInfo().IsDir() {
+				archive.zipEntries[entry.Name] = entry
+			}
+		}
+		return archive, nil
+	}
+
+	archiveFile, err := os.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	gzipReader, err := gzip.NewReader(archiveFile)
+	if err != nil {
+		archiveFile.Close()
+		return nil, err
+	}
+	return &cachedArchive{tarFile: archiveFile, tarReader: tar.NewReader(gzipReader), tarEntries: make(map[string][]byte)}, nil
+}
+
+// read returns the content of an entry. found is false, if the archive doesn't contain the entry
+func (archive *cachedArchive) read(entryPath string) (content []byte, found bool, err error) {
+	if archive.zipReader != nil {
+		entry, found := archive.zipEntries[entryPath]
+		if !found {
+			return nil, false, nil
+		}
+		entryReader, err := entry.Open()
+		if err != nil {
+			return nil, true, err
+		}
+		defer entryReader.Close()
+		content, err = ioutil.ReadAll(entryReader)
+		return content, true, err
+	}
+
+	if content, found := archive.tarEntries[entryPath]; found {
+		return content, true, nil
+	}
+	for archive.tarReader != nil {
+		header, err := archive.tarReader.Next()
+		if err == io.EOF {
+			archive.close()
+			return nil, false, nil
+		}
+		if err != nil {
+			archive.close()
+			return nil, false, err
+		}
+		path := strings.TrimPrefix(header.Name, "./")
+		if header.Typeflag != tar.TypeReg || (path != entryPath && !hasExtension(path, reportFileExtensions...)) {
+			continue
+		}
+		content, err := ioutil.ReadAll(archive.tarReader)
+		if err != nil {
+			archive.close()
+			return nil, false, err
+		}
+		archive.tarEntries[path] = content
+		if path == entryPath {
+			return content, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+func (archive *cachedArchive) close() {
+	if archive.zipReader != nil {
+		archive.zipReader.Close()
+		archive.zipReader = nil
+		archive.zipEntries = nil
+	}
+	if archive.tarFile != nil {
+		archive.tarFile.Close()
+		archive.tarFile = nil
+		archive.tarReader = nil
+	}
+}
+
+// ReadReportFile reads a test report file (as referenced by TestCase.ReportFileName). Test reports within
+// archives (e.g. test-results.zip!/TEST-MyTest.xml) are read from the archive. The archives are kept open (see
+// CloseArchives), as usually several test reports of the same archive are read
+func ReadReportFile(reportFileName string) ([]byte, error) {
+	i := strings.Index(reportFileName, ArchivePathSeparator)
+	if i == -1 || !isArchive(reportFileName[:i]) {
+		return ioutil.ReadFile(reportFileName)
+	}
+
+	archivePath, entryPath := reportFileName[:i], reportFileName[i+len(ArchivePathSeparator):]
+
+	archiveCache.Lock()
+	defer archiveCache.Unlock()
+	archive, cached := archiveCache.archives[archivePath]
+	if !cached {
+		var err error
+		if archive, err = openCachedArchive(archivePath); err != nil {
+			return nil, err
+		}
+		archiveCache.archives[archivePath] = archive
+	}
+
+	content, found, err := archive.read(entryPath)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("%s not found in archive %s", entryPath, archivePath)
+	}
+	return content, nil
+}
+
+// CloseArchives closes the archives opened by ReadReportFile and drops the test reports kept in memory
+func CloseArchives() {
+	archiveCache.Lock()
+	defer archiveCache.Unlock()
+	for archivePath, archive := range archiveCache.archives {
+		archive.close()
+		delete(archiveCache.archives, archivePath)
+	}
+}
@@ -0,0 +1,143 @@
+package testreport
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var archivedReports = []struct {
+	name    string
+	content string
+}{
+	{"surefire-reports/TEST-com.sap.ctm.testing.MyTest.xml", `<testsuite name="com.sap.ctm.testing.MyTest" tests="1"><testcase name="someTest" classname="com.sap.ctm.testing.MyTest"/></testsuite>`},
+	{"surefire-reports/README.txt", "Not a test report"},
+	{"surefire-reports/TEST-com.sap.ctm.testing.OtherTest.xml", `<testsuite name="com.sap.ctm.testing.OtherTest" tests="1"><testcase name="otherTest" classname="com.sap.ctm.testing.OtherTest"><failure message="failed"/></testcase></testsuite>`},
+}
+
+func writeZipArchive(t *testing.T, archivePath string) {
+	f, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	for _, report := range archivedReports {
+		w, err := zw.Create(report.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(report.content))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeTarGzArchive(t *testing.T, archivePath string) {
+	f, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+	tw.WriteHeader(&tar.Header{Name: "./surefire-reports/", Typeflag: tar.TypeDir, Mode: 0755})
+	for _, report := range archivedReports {
+		tw.WriteHeader(&tar.Header{Name: "./" + report.name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(report.content))})
+		tw.Write([]byte(report.content))
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseArchivedReports(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ctm-archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	zipPath := filepath.Join(dir, "test-results.zip")
+	tarGzPath := filepath.Join(dir, "test-results.tar.gz")
+	writeZipArchive(t, zipPath)
+	writeTarGzArchive(t, tarGzPath)
+
+	xtr := XUTestReport{}
+	for _, archivePath := range []string{zipPath, tarGzPath} {
+		// Archive given directly
+		ts := xtr.Parse(archivePath)
+		if len(ts) != 2 {
+			t.Fatal("Should parse exactly two test suites from ", archivePath, ", got ", len(ts))
+		}
+		checkTestCases(t, ts[0].TestCase, []expectedTestCase{{"com.sap.ctm.testing.MyTest", "someTest", SUCCESS}})
+		checkTestCases(t, ts[1].TestCase, []expectedTestCase{{"com.sap.ctm.testing.OtherTest", "otherTest", FAILURE}})
+
+		reportFileName := ts[1].TestCase[0].ReportFileName
+		if reportFileName != archivePath+"!/surefire-reports/TEST-com.sap.ctm.testing.OtherTest.xml" {
+			t.Error("Invalid report file name: ", reportFileName)
+		}
+		content, err := ReadReportFile(reportFileName)
+		if err != nil || string(content) != archivedReports[2].content {
+			t.Error("Unable to read archived report ", reportFileName, ": ", err)
+		}
+	}
+
+	// Directory containing archives
+	ts := xtr.Parse(dir)
+	if len(ts) != 4 {
+		t.Error("Should parse exactly four test suites from directory, got ", len(ts))
+	}
+
+	for _, archivePath := range []string{zipPath, tarGzPath} {
+		if _, err := ReadReportFile(archivePath + "!/surefire-reports/TEST-Unknown.xml"); err == nil {
+			t.Error("Reading a file which is not in the archive should fail")
+		}
+		// Files read before are still available
+		content, err := ReadReportFile(archivePath + "!/" + archivedReports[0].name)
+		if err != nil || string(content) != archivedReports[0].content {
+			t.Error("Unable to read archived report again: ", err)
+		}
+	}
+	CloseArchives()
+}
+
+func TestReadReportFileStopsAtEntry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ctm-archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tarGzPath := filepath.Join(dir, "test-results.tar.gz")
+	writeTarGzArchive(t, tarGzPath)
+	defer CloseArchives()
+
+	content, err := ReadReportFile(tarGzPath + "!/" + archivedReports[0].name)
+	if err != nil || string(content) != archivedReports[0].content {
+		t.Fatal("Unable to read archived report: ", err)
+	}
+	archive := archiveCache.archives[tarGzPath]
+	if archive == nil || archive.tarReader == nil || len(archive.tarEntries) != 1 {
+		t.Fatal("Archive should only be read up to the requested entry")
+	}
+
+	// The next entry is read from where the archive was left
+	content, err = ReadReportFile(tarGzPath + "!/" + archivedReports[2].name)
+	if err != nil || string(content) != archivedReports[2].content {
+		t.Fatal("Unable to read archived report: ", err)
+	}
+	if archiveCache.archives[tarGzPath] != archive {
+		t.Error("Archive should be opened only once")
+	}
+}
@@ -0,0 +1,138 @@
+package testreport
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"time"
+
+	"github.com/SAP/quality-continuous-traceability-monitor/utils"
+	"github.com/golang/glog"
+)
+
+// Parsers for the test report types which can be detected per file. Allure results are handled separately,
+// as they can only be mapped once all files have been read
+var reportFileParsers = map[string]reportContentParser{
+	"xunit-xml": func(xmlFilePath string, content []byte, ts []TestSuite) []TestSuite {
+		return parseXunitFile(xmlFilePath, bytes.NewReader(content), ts)
+	},
+	"testng-xml":          parseTestNGFile,
+	"nunit-xml":           parseNUnitFile,
+	"trx":                 parseTRXFile,
+	"cucumber-json":       parseCucumberFile,
+	"go-test-json":        parseGoTestFile,
+	"tap":                 parseTAPFile,
+	"robot-xml":           parseRobotFile,
+	"jest-json":           parseJestFile,
+	"mocha-json":          parseMochaFile,
+	"ctrf-json":           parseCTRFFile,
+	"pytest-json":         parsePytestFile,
+	"playwright-json":     parsePlaywrightFile,
+	"open-test-reporting": parseOTRFile,
+}
+
+// Root XML elements of the XML based test report types
+var xmlRootElements = map[string]string{
+	"testsuite":      "xunit-xml",
+	"testsuites":     "xunit-xml",
+	"testng-results": "testng-xml",
+	"test-run":       "nunit-xml",
+	"TestRun":        "trx",
+	"robot":          "robot-xml",
+	"events":         "open-test-reporting",
+}
+
+// AutoTestReport test report structure for test report directories with mixed test report formats
+type AutoTestReport struct {
+}
+
+// Parse all test result reports in a directory. The format of each file is detected on its own (see detectTestReportType)
+func (autotr *AutoTestReport) Parse(reportRootPath string) []TestSuite {
+	defer utils.TimeTrack(time.Now(), "Scan test reports (auto detect)")
+
+	var allureFiles allureCollector
+	ts := parseReportFiles(reportRootPath, func(path string) bool {
+		return hasExtension(path, reportFileExtensions...)
+	}, readContent(func(reportFilePath string, content []byte, ts []TestSuite) []TestSuite {
+		reportType := detectTestReportType(reportFilePath, content)
+		switch reportType {
+		case "":
+			glog.Info("Unknown test report format ", reportFilePath)
+			return ts
+		case "allure":
+			allureFiles.collect(reportFilePath, content)
+			return ts
+		default:
+			glog.Info("Detected test report type ", reportType, " for ", reportFilePath)
+			return reportFileParsers[reportType](reportFilePath, content, ts)
+		}
+	}))
+
+	return allureFiles.addToTestResult(ts)
+}
+
+// Detect the test report type of a file by its content. XML files are detected by their root element,
+// JSON files by the keys of their (first) JSON object. An empty string is returned for unknown formats
+func detectTestReportType(reportFilePath string, content []byte) string {
+	content = bytes.TrimSpace(content)
+	if len(content) == 0 {
+		return ""
+	}
+
+	switch content[0] {
+	case '<':
+		return detectXMLTestReportType(content)
+	case '[': // Cucumber is the only format with a JSON array (of features) as root
+		return "cucumber-json"
+	case '{':
+		return detectJSONTestReportType(reportFilePath, content)
+	}
+
+	if hasExtension(reportFilePath, ".tap") || bytes.HasPrefix(content, []byte("TAP version")) {
+		return "tap"
+	}
+	return ""
+}
+
+func detectXMLTestReportType(content []byte) string {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return ""
+		}
+		if root, ok := token.(xml.StartElement); ok {
+			return xmlRootElements[root.Name.Local]
+		}
+	}
+}
+
+// Only the first JSON object is decoded, as some formats (go test -json, pytest-reportlog) write one JSON object per line
+func detectJSONTestReportType(reportFilePath string, content []byte) string {
+	var keys map[string]json.RawMessage
+	if err := json.NewDecoder(bytes.NewReader(content)).Decode(&keys); err != nil {
+		return ""
+	}
+	has := func(key string) bool {
+		_, found := keys[key]
+		return found
+	}
+
+	switch {
+	case has("Action") && has("Package"):
+		return "go-test-json"
+	case has("$report_type"), has("exitcode") && has("tests"):
+		return "pytest-json"
+	case has("results"):
+		return "ctrf-json"
+	case has("config") && has("suites"):
+		return "playwright-json"
+	case has("testResults") && has("numTotalTests"):
+		return "jest-json"
+	case has("stats") && has("passes"):
+		return "mocha-json"
+	case has("uuid") && isAllureFile(reportFilePath):
+		return "allure"
+	}
+	return ""
+}
@@ -0,0 +1,99 @@
+package testreport
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectTestReportType(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		content  string
+		expected string
+	}{
+		{"xunit testsuite", "TEST-MyTest.xml", `<?xml version="1.0"?><testsuite name="MyTest"/>`, "xunit-xml"},
+		{"xunit testsuites", "results.xml", `<testsuites><testsuite name="MyTest"/></testsuites>`, "xunit-xml"},
+		{"testng", "testng-results.xml", `<testng-results total="0"/>`, "testng-xml"},
+		{"nunit", "TestResult.xml", `<test-run id="2"/>`, "nunit-xml"},
+		{"trx", "results.trx", `<?xml version="1.0"?><TestRun xmlns="http://microsoft.com/schemas/VisualStudio/TeamTest/2010"/>`, "trx"},
+		{"robot", "output.xml", `<!-- Robot --><robot generator="Robot 6.1"/>`, "robot-xml"},
+		{"open test reporting", "open-test-report.xml", `<e:events xmlns:e="https://schemas.opentest4j.org/reporting/events/0.1.0"/>`, "open-test-reporting"},
+		{"unknown xml", "pom.xml", `<project/>`, ""},
+		{"cucumber", "cucumber.json", `[{"keyword": "Feature", "elements": []}]`, "cucumber-json"},
+		{"go test", "gotest.json", "{\"Action\":\"run\",\"Package\":\"a/b\",\"Test\":\"TestX\"}\n{\"Action\":\"pass\",\"Package\":\"a/b\",\"Test\":\"TestX\"}", "go-test-json"},
+		{"pytest json report", "report.json", `{"created": 1, "exitcode": 0, "tests": []}`, "pytest-json"},
+		{"pytest reportlog", "log.jsonl", "{\"pytest_version\": \"7.0\", \"$report_type\": \"SessionStart\"}\n{\"$report_type\": \"SessionFinish\"}", "pytest-json"},
+		{"ctrf", "ctrf-report.json", `{"results": {"tool": {"name": "jest"}, "tests": []}}`, "ctrf-json"},
+		{"playwright", "results.json", `{"config": {}, "suites": [], "stats": {}}`, "playwright-json"},
+		{"jest", "jest.json", `{"numTotalTests": 0, "testResults": []}`, "jest-json"},
+		{"mocha", "mocha.json", `{"stats": {}, "tests": [], "passes": []}`, "mocha-json"},
+		{"allure", "0a1b-result.json", `{"uuid": "0a1b", "name": "someTest", "status": "passed"}`, "allure"},
+		{"allure container", "0a1b-container.json", `{"uuid": "0a1c", "children": ["0a1b"]}`, "allure"},
+		{"unknown json", "package.json", `{"name": "my-package"}`, ""},
+		{"tap", "results.tap", "1..1\nok 1 - someTest", "tap"},
+		{"tap version", "results.txt", "TAP version 13\n1..1\nok 1 - someTest", "tap"},
+		{"empty", "empty.json", "", ""},
+	}
+
+	for _, test := range tests {
+		if actual := detectTestReportType(test.path, []byte(test.content)); actual != test.expected {
+			t.Errorf("%s: Expected test report type '%s', got '%s'", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestAutoTestReportParse(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ctm-auto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"TEST-com.sap.ctm.testing.MyTest.xml": `<testsuite name="com.sap.ctm.testing.MyTest" tests="1"><testcase name="someTest" classname="com.sap.ctm.testing.MyTest"/></testsuite>`,
+		"results.trx": `<TestRun xmlns="http://microsoft.com/schemas/VisualStudio/TeamTest/2010">
+				<Results><UnitTestResult testId="1" testName="SomeTest" outcome="Failed"/></Results>
+				<TestDefinitions><UnitTest id="1" name="SomeTest"><TestMethod className="MyCompany.MyTests, MyTests" name="SomeTest"/></UnitTest></TestDefinitions>
+			</TestRun>`,
+		"jest.json":    `{"numTotalTests": 1, "testResults": [{"name": "/src/sum.test.js", "assertionResults": [{"ancestorTitles": ["sum"], "title": "adds", "status": "passed"}]}]}`,
+		"package.json": `{"name": "my-package"}`,
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	atr := AutoTestReport{}
+	ts := atr.Parse(dir)
+
+	// Files are walked in lexical order
+	if len(ts) != 3 {
+		t.Fatal("Should parse exactly three test suites, got ", len(ts))
+	}
+	checkTestCases(t, ts[0].TestCase, []expectedTestCase{{"com.sap.ctm.testing.MyTest", "someTest", SUCCESS}})
+	checkTestCases(t, ts[1].TestCase, []expectedTestCase{{"sum", "adds", SUCCESS}})
+	checkTestCases(t, ts[2].TestCase, []expectedTestCase{{"MyCompany.MyTests", "SomeTest", FAILURE}})
+}
+
+func TestGetTestReport(t *testing.T) {
+	if _, found := GetTestReport("unknown"); found {
+		t.Error("Unknown test report type should not be supported")
+	}
+	if tr, found := GetTestReport("auto"); !found || tr == nil {
+		t.Error("auto test report type should be supported")
+	}
+
+	RegisterTestReport("my-format", &XUTestReport{})
+	defer func() { testReportRegistry = testReportRegistry[:len(testReportRegistry)-1] }()
+	if _, found := GetTestReport("my-format"); !found {
+		t.Error("Registered test report type should be supported")
+	}
+	types := SupportedTestReportTypes()
+	if types[0] != "xunit-xml" || types[len(types)-1] != "my-format" {
+		t.Error("Supported test report types should keep the registration order: ", types)
+	}
+}
@@ -1,5 +1,20 @@
 package testreport
 
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/golang/glog"
+)
+
 const (
 	// SUCCESS result of automated test
 	SUCCESS int = 0
@@ -9,8 +24,19 @@ const (
 	ERROR int = 2
 	// SKIPPED result of automated test
 	SKIPPED int = 3
+	// FLAKY result of automated test (test passed, but only after it failed before, e.g. on retry)
+	FLAKY int = 4
 )
 
+// Test results ordered by their severity, the most severe first (e.g. a failed assertion outweighs an infrastructure error)
+var resultSeverity = []int{FAILURE, ERROR, SKIPPED, FLAKY, SUCCESS}
+
+// File extensions of all supported test report types
+var reportFileExtensions = []string{".xml", ".trx", ".json", ".jsonl", ".tap"}
+
+// Maximum length of the standard output/error kept per test case
+const maxOutputLength = 4096
+
 // TestReport interface implements the parse method which parses test reports
 type TestReport interface {
 	Parse(reportRootPath string) []TestSuite
@@ -21,7 +47,18 @@ type TestCase struct {
 	ReportFileName, // Test report file (e.g. Surefire XML)
 	ClassName, // Test class
 	MethodName string // Test method
-	Result int // Test result
+	Result      int                 // Test result
+	Tags        []string            // Tags, labels and links of the test case in the test report (e.g. Jira:ABC-1). Might be used for requirement mapping
+	Variant     string              // Variant the test case was run in (e.g. a Playwright project like webkit), if the same test case runs in multiple variants
+	Environment string              // Environment the test case was run in (label of the test report in the configuration, e.g. linux-jdk17)
+	Message     string              // Failure, error or skip message (if any)
+	StackTrace  string              // Stack trace (or further details) of the failure (if any)
+	Duration    time.Duration       // Duration of the test (0 if not reported)
+	Stdout      string              // Standard output of the test (truncated to maxOutputLength)
+	Stderr      string              // Standard error output of the test (truncated to maxOutputLength)
+	Timestamp   time.Time           // Start of the test (or of its test suite). The modification time of the report file, if not reported
+	Attempts    []*TestCase         // Superseded runs of the test case (e.g. in retried CI jobs), which were merged into this one (see AggregateTestCases)
+	Properties  map[string][]string // Properties (name and values) of the test case incl. the ones of its test suite (e.g. xunit property elements). Might be used for requirement mapping
 }
 
 // TestSuite is a collection of TestCase
@@ -29,3 +66,189 @@ type TestSuite struct {
 	Name     string      // Name of Testsuite
 	TestCase []*TestCase // Array of Testcases
 }
+
+// reportFileParser parses a single test report file (read from content) and adds the found test suites to ts
+type reportFileParser func(reportFilePath string, content io.Reader, ts []TestSuite) []TestSuite
+
+// reportContentParser parses the (complete) content of a single test report file and adds the found test suites to ts
+type reportContentParser func(reportFilePath string, content []byte, ts []TestSuite) []TestSuite
+
+// readContent reads a test report file into memory for parsers which need its complete content (e.g. to unmarshal it)
+func readContent(parseContent reportContentParser) reportFileParser {
+	return func(reportFilePath string, content io.Reader, ts []TestSuite) []TestSuite {
+		fileContent, err := ioutil.ReadAll(content)
+		if err != nil {
+			glog.Error("Unable to read file ", reportFilePath, ": ", err)
+			return ts
+		}
+		return parseContent(reportFilePath, fileContent, ts)
+	}
+}
+
+// Number of test report files parsed concurrently
+var reportFileWorkers = runtime.NumCPU()
+
+// parseReportFiles walks through reportRootPath and passes every file accepted by match to parseFile. Archives
+// (.zip and .tar.gz) are read without extracting them and the files within them are handled the same way.
+// reportRootPath might point to an archive directly.
+// The files are parsed concurrently (parseFile must therefore be safe for concurrent use), but the test suites
+// are returned in the order the files were found, so that the result is the same on every run
+func parseReportFiles(reportRootPath string, match func(path string) bool, parseFile reportFileParser) []TestSuite {
+
+	var reportFiles []string
+	filepath.Walk(reportRootPath, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			glog.Error("Unable to access ", path, ": ", err)
+			return nil
+		}
+
+		if fi.IsDir() || (!isArchive(path) && !match(path)) {
+			return nil
+		}
+		reportFiles = append(reportFiles, path)
+
+		return nil
+	})
+
+	// Each worker parses one file at a time and keeps its test suites at the index of the file
+	var fileSuites = make([][]TestSuite, len(reportFiles))
+	var fileIndexes = make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < reportFileWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range fileIndexes {
+				fileSuites[i] = parseReportFile(reportFiles[i], match, parseFile)
+			}
+		}()
+	}
+	for i := range reportFiles {
+		fileIndexes <- i
+	}
+	close(fileIndexes)
+	wg.Wait()
+
+	var ts = []TestSuite{}
+	for _, suites := range fileSuites {
+		ts = append(ts, suites...)
+	}
+	return ts
+}
+
+// parseReportFile parses a single test report file or all test report files (accepted by match) within an archive
+func parseReportFile(path string, match func(path string) bool, parseFile reportFileParser) []TestSuite {
+	var ts []TestSuite
+
+	if isArchive(path) {
+		glog.Info("Reading archive ", path)
+		err := readArchive(path, match, func(entryPath string, content io.Reader, modTime time.Time) {
+			reportFileName := path + ArchivePathSeparator + entryPath
+			glog.Info("Parsing ", reportFileName)
+			parsed := len(ts)
+			ts = parseFile(reportFileName, content, ts)
+			setDefaultTimestamp(ts[parsed:], modTime)
+		})
+		if err != nil {
+			glog.Error("Unable to read archive ", path, ": ", err)
+		}
+		return ts
+	}
+
+	glog.Info("Parsing ", path)
+	file, err := os.Open(path)
+	if err != nil {
+		glog.Error("Unable to read file: ", err)
+		return ts
+	}
+	defer file.Close()
+	ts = parseFile(path, file, ts)
+	if fi, err := file.Stat(); err == nil {
+		setDefaultTimestamp(ts, fi.ModTime())
+	}
+	return ts
+}
+
+// Test cases without a timestamp of their own get the modification time of their report file. So the latest run of a
+// test can still be told apart from earlier ones (see AggregateTestCases)
+func setDefaultTimestamp(ts []TestSuite, timestamp time.Time) {
+	for _, s := range ts {
+		for _, tc := range s.TestCase {
+			if tc.Timestamp.IsZero() {
+				tc.Timestamp = timestamp
+			}
+		}
+	}
+}
+
+// GetSeverity returns the severity of a test result, i.e. its index in resultSeverity (0 is the most severe). Unknown
+// test results are most severe
+func GetSeverity(result int) int {
+	for i, r := range resultSeverity {
+		if r == result {
+			return i
+		}
+	}
+	return 0
+}
+
+// hasExtension checks (case insensitive) whether the given file path ends with one of the given extensions
+func hasExtension(path string, extensions ...string) bool {
+	for _, ext := range extensions {
+		if strings.EqualFold(filepath.Ext(path), ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// truncateOutput shortens test output to maxOutputLength. The end of the output is kept, as it usually shows the
+// cause of a failure
+func truncateOutput(output string) string {
+	output = strings.TrimSpace(output)
+	if len(output) <= maxOutputLength {
+		return output
+	}
+	start := len(output) - maxOutputLength
+	for start < len(output) && !utf8.RuneStart(output[start]) {
+		start++
+	}
+	return "[...]\n" + output[start:]
+}
+
+// parseSeconds parses a duration given in (fractional) seconds (e.g. 0.123 or 1,234.5). 0 is returned for invalid values
+func parseSeconds(seconds string) time.Duration {
+	s, err := strconv.ParseFloat(strings.Replace(strings.TrimSpace(seconds), ",", "", -1), 64)
+	if err != nil {
+		return 0
+	}
+	return time.Duration(s * float64(time.Second))
+}
+
+// parseMilliseconds converts a duration given in (fractional) milliseconds
+func parseMilliseconds(milliseconds float64) time.Duration {
+	return time.Duration(milliseconds * float64(time.Millisecond))
+}
+
+// Layouts of the timestamps in test reports: ISO 8601 with or without time zone (e.g. 2017-11-09T13:47:34 as used by
+// xunit), with time zone abbreviation (e.g. 2017-11-09T13:47:34 CET as used by TestNG) and the one of Robot Framework < 7
+var timestampLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02T15:04:05 MST", robotTimeFormat}
+
+// parseTimestamp parses a timestamp given in one of the timestampLayouts. A zero time is returned for invalid values
+func parseTimestamp(timestamp string) time.Time {
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, strings.TrimSpace(timestamp)); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// firstLine returns the first (non empty) line of a text, e.g. to derive a failure message from a stack trace
+func firstLine(text string) string {
+	text = strings.TrimSpace(text)
+	if i := strings.Index(text, "\n"); i != -1 {
+		return strings.TrimSpace(text[:i])
+	}
+	return text
+}
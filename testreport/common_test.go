@@ -0,0 +1,108 @@
+package testreport
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// expectedTestCase describes the relevant fields of a parsed test case
+type expectedTestCase struct {
+	ClassName, MethodName string
+	Result                int
+}
+
+func checkTestCases(t *testing.T, actual []*TestCase, expected []expectedTestCase) {
+	if len(actual) != len(expected) {
+		t.Fatalf("Should parse exactly %d test cases, got %d", len(expected), len(actual))
+	}
+
+	for i, tc := range actual {
+		if tc.ClassName != expected[i].ClassName || tc.MethodName != expected[i].MethodName || tc.Result != expected[i].Result {
+			t.Errorf("Test case %d was parsed wrong.\nExpected: %s - %s => %d\nActual: %s - %s => %d", i, expected[i].ClassName, expected[i].MethodName, expected[i].Result, tc.ClassName, tc.MethodName, tc.Result)
+		}
+	}
+}
+
+func TestTruncateOutput(t *testing.T) {
+	if truncateOutput("  short output\n") != "short output" {
+		t.Error("Short output should not be truncated")
+	}
+
+	long := strings.Repeat("a", maxOutputLength) + "the end"
+	truncated := truncateOutput(long)
+	if !strings.HasPrefix(truncated, "[...]") || !strings.HasSuffix(truncated, "the end") {
+		t.Error("Long output should be truncated at the beginning")
+	}
+	if len(truncated) > maxOutputLength+len("[...]\n") {
+		t.Error("Truncated output is too long: ", len(truncated))
+	}
+}
+
+func TestParseSeconds(t *testing.T) {
+	if parseSeconds("1,234.5") != 1234500*time.Millisecond {
+		t.Error("Invalid duration was parsed: ", parseSeconds("1,234.5"))
+	}
+	if parseSeconds("") != 0 || parseSeconds("n/a") != 0 {
+		t.Error("Invalid durations should be 0")
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	tests := []struct {
+		timestamp string
+		expected  time.Time
+	}{
+		{"2017-11-09T13:47:34", time.Date(2017, 11, 9, 13, 47, 34, 0, time.UTC)},
+		{"2017-11-09T13:47:34.5", time.Date(2017, 11, 9, 13, 47, 34, 500000000, time.UTC)},
+		{"2017-11-09T14:47:34+01:00", time.Date(2017, 11, 9, 13, 47, 34, 0, time.UTC)},
+		{"2017-11-09T13:47:34 UTC", time.Date(2017, 11, 9, 13, 47, 34, 0, time.UTC)},
+		{"20171109 13:47:34.500", time.Date(2017, 11, 9, 13, 47, 34, 500000000, time.UTC)},
+		{"", time.Time{}},
+		{"yesterday", time.Time{}},
+	}
+
+	for _, test := range tests {
+		if actual := parseTimestamp(test.timestamp); !actual.Equal(test.expected) {
+			t.Errorf("Expected timestamp %v for '%s', got %v", test.expected, test.timestamp, actual)
+		}
+	}
+}
+
+func TestParseReportFilesKeepsOrder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ctm-parse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for i := 0; i < 100; i++ {
+		content := fmt.Sprintf(`<testsuites><testsuite name="Suite%03da"><testcase name="test" classname="Test%03d"/></testsuite><testsuite name="Suite%03db"><testcase name="test" classname="Test%03d"/></testsuite></testsuites>`, i, i, i, i)
+		if err := ioutil.WriteFile(filepath.Join(dir, fmt.Sprintf("TEST-%03d.xml", i)), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	defer func(workers int) { reportFileWorkers = workers }(reportFileWorkers)
+	reportFileWorkers = 8
+
+	xtr := XUTestReport{}
+	for run := 0; run < 3; run++ {
+		ts := xtr.Parse(dir)
+		if len(ts) != 200 {
+			t.Fatal("Should parse exactly 200 test suites, got ", len(ts))
+		}
+		if ts[0].TestCase[0].Timestamp.IsZero() {
+			t.Error("Test cases without timestamp should get the modification time of their report file")
+		}
+		for i := 0; i < 100; i++ {
+			if ts[2*i].Name != fmt.Sprintf("Suite%03da", i) || ts[2*i+1].Name != fmt.Sprintf("Suite%03db", i) {
+				t.Fatal("Test suites are not in file order: ", ts[2*i].Name, " at index ", 2*i)
+			}
+		}
+	}
+}
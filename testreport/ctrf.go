@@ -0,0 +1,155 @@
+package testreport
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/SAP/quality-continuous-traceability-monitor/utils"
+	"github.com/golang/glog"
+)
+
+// CTRFTest CTRF test structure
+type CTRFTest struct {
+	Name     string                     `json:"name"`
+	Status   string                     `json:"status"`
+	Duration float64                    `json:"duration"`
+	Suite    json.RawMessage            `json:"suite,omitempty"` // String (e.g. "Login > valid user") or list of suite names
+	FilePath string                     `json:"filePath,omitempty"`
+	Message  string                     `json:"message,omitempty"`
+	Trace    string                     `json:"trace,omitempty"`
+	Retries  int                        `json:"retries,omitempty"`
+	Flaky    bool                       `json:"flaky,omitempty"`
+	Tags     []string                   `json:"tags,omitempty"`
+	Extra    map[string]json.RawMessage `json:"extra,omitempty"`
+}
+
+// CTRFTool CTRF tool struct
+type CTRFTool struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// CTRFResults CTRF results structure
+type CTRFResults struct {
+	Tool  *CTRFTool   `json:"tool"`
+	Tests []*CTRFTest `json:"tests"`
+}
+
+// CTRFReport CTRF (Common Test Report Format) report structure
+type CTRFReport struct {
+	ReportFormat string       `json:"reportFormat,omitempty"`
+	SpecVersion  string       `json:"specVersion,omitempty"`
+	Results      *CTRFResults `json:"results"`
+}
+
+// CTRFTestReport CTRF JSON test report structure
+type CTRFTestReport struct {
+}
+
+// Parse CTRF (Common Test Report Format) JSON test result reports
+func (ctrftr *CTRFTestReport) Parse(reportRootPath string) []TestSuite {
+	defer utils.TimeTrack(time.Now(), "Scan CTRF JSON test reports")
+
+	return parseReportFiles(reportRootPath, func(path string) bool {
+		return hasExtension(path, ".json")
+	}, readContent(parseCTRFFile))
+}
+
+func parseCTRFFile(jsonFilePath string, jfb []byte, ts []TestSuite) []TestSuite {
+	var ctrfReport CTRFReport
+	err := json.Unmarshal(jfb, &ctrfReport)
+	if err != nil || ctrfReport.Results == nil || ctrfReport.Results.Tests == nil {
+		glog.Info("Not a CTRF JSON file ", jsonFilePath, ": ", err)
+		return ts
+	}
+
+	var suites []TestSuite
+	var suiteIndex = make(map[string]int)
+	for _, test := range ctrfReport.Results.Tests {
+		className := test.getClassName()
+		testcase := &TestCase{ReportFileName: jsonFilePath, ClassName: className, MethodName: test.Name, Result: getCTRFResult(test.Status), Tags: test.getTags(),
+			Message: test.Message, StackTrace: strings.TrimSpace(test.Trace), Duration: parseMilliseconds(test.Duration)}
+
+		i, found := suiteIndex[className]
+		if !found {
+			i = len(suites)
+			suiteIndex[className] = i
+			suites = append(suites, TestSuite{Name: className})
+		}
+		suites[i].TestCase = append(suites[i].TestCase, testcase)
+	}
+
+	return append(ts, suites...)
+}
+
+// The suite is used as class name. Nested suites are joined with spaces (e.g. "Login valid user").
+// Tests without suite get their file path as class name
+func (ctrft *CTRFTest) getClassName() string {
+	if len(ctrft.Suite) > 0 {
+		var suite string
+		if json.Unmarshal(ctrft.Suite, &suite) == nil && suite != "" {
+			return strings.Join(strings.Split(suite, " > "), " ")
+		}
+		var suites []string
+		if json.Unmarshal(ctrft.Suite, &suites) == nil && len(suites) > 0 {
+			return strings.Join(suites, " ")
+		}
+	}
+	return ctrft.FilePath
+}
+
+// Collect the tags of a CTRF test. Requirement keys might also be given in the extra field, either as
+// backlog item references (e.g. "requirements": ["Jira:ABC-1"]) or per backlog system (e.g. "jira": ["ABC-1"])
+func (ctrft *CTRFTest) getTags() []string {
+	var tags []string
+	tags = append(tags, ctrft.Tags...)
+
+	// Sort the extra keys to get the same tags (order) every run
+	var keys []string
+	for key := range ctrft.Extra {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		var prefix string
+		switch strings.ToLower(key) {
+		case "requirements", "requirement":
+			prefix = ""
+		case "jira":
+			prefix = "Jira:"
+		case "github":
+			prefix = "GitHub:"
+		default:
+			continue
+		}
+		for _, v := range getStringOrStrings(ctrft.Extra[key]) {
+			tags = append(tags, prefix+v)
+		}
+	}
+	return tags
+}
+
+func getStringOrStrings(value json.RawMessage) []string {
+	var s string
+	if json.Unmarshal(value, &s) == nil {
+		return []string{s}
+	}
+	var ss []string
+	json.Unmarshal(value, &ss)
+	return ss
+}
+
+// Map CTRF test status (passed, failed, skipped, pending, other) to our test result
+func getCTRFResult(status string) int {
+	switch status {
+	case "passed":
+		return SUCCESS
+	case "skipped", "pending":
+		return SKIPPED
+	default: // failed, other
+		return FAILURE
+	}
+}
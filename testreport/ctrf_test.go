@@ -0,0 +1,64 @@
+package testreport
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestParseCTRFFile(t *testing.T) {
+	fp := "ctrf-report.json"
+
+	x := []byte(`{
+		"reportFormat": "CTRF",
+		"specVersion": "0.0.0",
+		"results": {
+			"tool": {"name": "jest"},
+			"summary": {"tests": 5, "passed": 1, "failed": 1, "pending": 1, "skipped": 1, "other": 1, "start": 1, "stop": 2},
+			"tests": [
+				{"name": "logs in", "status": "passed", "duration": 100, "suite": "Login > with valid user", "filePath": "test/login.test.js",
+				 "extra": {"requirements": ["Jira:MYJIRAPROJECT-1"], "jira": "MYJIRAPROJECT-2", "github": ["myOrg/myRepo#5"], "owner": "team-a"}},
+				{"name": "shows an error", "status": "failed", "duration": 20, "suite": ["Login", "with invalid user"], "message": "expected error"},
+				{"name": "remembers the user", "status": "pending", "duration": 0, "suite": "Login"},
+				{"name": "resets the password", "status": "skipped", "duration": 0, "suite": "Login"},
+				{"name": "logs out", "status": "other", "duration": 0, "filePath": "test/logout.test.js", "tags": ["@smoke"]}
+			]
+		}
+	}`)
+
+	var ts = []TestSuite{}
+	ts = parseCTRFFile(fp, x, ts)
+
+	if len(ts) != 4 {
+		t.Fatal("Should parse exactly four test suites (one per suite)")
+	}
+	checkTestCases(t, append(append(append(ts[0].TestCase, ts[1].TestCase...), ts[2].TestCase...), ts[3].TestCase...), []expectedTestCase{
+		{"Login with valid user", "logs in", SUCCESS},
+		{"Login with invalid user", "shows an error", FAILURE},
+		{"Login", "remembers the user", SKIPPED},
+		{"Login", "resets the password", SKIPPED},
+		{"test/logout.test.js", "logs out", FAILURE},
+	})
+
+	tags := ts[0].TestCase[0].Tags
+	sort.Strings(tags)
+	if strings.Join(tags, ",") != "GitHub:myOrg/myRepo#5,Jira:MYJIRAPROJECT-1,Jira:MYJIRAPROJECT-2" {
+		t.Error("Invalid tags were parsed: ", tags)
+	}
+	if strings.Join(ts[3].TestCase[0].Tags, ",") != "@smoke" {
+		t.Error("Invalid tags were parsed: ", ts[3].TestCase[0].Tags)
+	}
+}
+
+func TestParseCTRFFileIgnoresOtherJSON(t *testing.T) {
+	fp := "package.json"
+
+	x := []byte(`{"name": "my-app", "version": "1.0.0"}`)
+
+	var ts = []TestSuite{}
+	ts = parseCTRFFile(fp, x, ts)
+
+	if len(ts) != 0 {
+		t.Error("Should not parse a test suite from a non CTRF JSON file")
+	}
+}
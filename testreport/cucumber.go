@@ -0,0 +1,187 @@
+package testreport
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SAP/quality-continuous-traceability-monitor/utils"
+	"github.com/golang/glog"
+)
+
+// CucumberTag Cucumber JSON tag struct
+type CucumberTag struct {
+	Name string `json:"name"`
+}
+
+// CucumberResult Cucumber JSON step result struct
+type CucumberResult struct {
+	Status       string `json:"status"`
+	Duration     int64  `json:"duration,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
+
+// CucumberStep Cucumber JSON step (or hook) structure
+type CucumberStep struct {
+	Keyword string          `json:"keyword,omitempty"`
+	Name    string          `json:"name,omitempty"`
+	Line    int             `json:"line,omitempty"`
+	Result  *CucumberResult `json:"result,omitempty"`
+}
+
+// CucumberElement Cucumber JSON element (scenario or background) structure
+type CucumberElement struct {
+	ID      string          `json:"id"`
+	Keyword string          `json:"keyword"`
+	Name    string          `json:"name"`
+	Line    int             `json:"line"`
+	Type    string          `json:"type"`
+	Tags    []*CucumberTag  `json:"tags,omitempty"`
+	Before  []*CucumberStep `json:"before,omitempty"`
+	Steps   []*CucumberStep `json:"steps,omitempty"`
+	After   []*CucumberStep `json:"after,omitempty"`
+}
+
+// CucumberFeature Cucumber JSON feature structure
+type CucumberFeature struct {
+	URI      string             `json:"uri"`
+	ID       string             `json:"id"`
+	Keyword  string             `json:"keyword"`
+	Name     string             `json:"name"`
+	Tags     []*CucumberTag     `json:"tags,omitempty"`
+	Elements []*CucumberElement `json:"elements,omitempty"`
+}
+
+// CucumberTestReport Cucumber JSON test report structure
+type CucumberTestReport struct {
+}
+
+// Parse Cucumber JSON test result reports
+func (ctr *CucumberTestReport) Parse(reportRootPath string) []TestSuite {
+	defer utils.TimeTrack(time.Now(), "Scan Cucumber JSON test reports")
+
+	return parseReportFiles(reportRootPath, func(path string) bool {
+		return hasExtension(path, ".json")
+	}, readContent(parseCucumberFile))
+}
+
+func parseCucumberFile(jsonFilePath string, jfb []byte, ts []TestSuite) []TestSuite {
+	var features []*CucumberFeature
+	err := json.Unmarshal(jfb, &features)
+	if err != nil {
+		glog.Info("Not a Cucumber JSON file ", jsonFilePath, ": ", err)
+		return ts
+	}
+
+	var found = len(ts)
+	for _, feature := range features {
+		ts = addCucumberFeatureToTestResult(jsonFilePath, ts, *feature)
+	}
+	if found == len(ts) {
+		glog.Info("No test cases found in ", jsonFilePath)
+	}
+
+	return ts
+}
+
+// Map the Cucumber feature (and within that its scenarios) to the common (generalized) TestSuite struct.
+// Feature name is used as class name, scenario name as method name. Cucumber already expands scenario outlines
+// into one element per example row. Those get the number of the example row attached to the method name
+// (e.g. "Login with user 2"), similar to data driven Gauge scenarios
+func addCucumberFeatureToTestResult(jsonFile string, ts []TestSuite, feature CucumberFeature) []TestSuite {
+	var testcases []*TestCase
+	var background []*CucumberStep
+	var exampleRows = make(map[string]int)
+	for _, element := range feature.Elements {
+		// Background steps are reported as separate element, which belongs to the following scenario
+		if element.Type == "background" {
+			background = element.Steps
+			continue
+		}
+
+		var hooks, steps []*CucumberStep
+		hooks = append(hooks, element.Before...)
+		hooks = append(hooks, element.After...)
+		steps = append(steps, background...)
+		steps = append(steps, element.Steps...)
+		background = nil
+
+		methodName := element.Name
+		if isCucumberScenarioOutline(element) {
+			outline := getCucumberOutlineID(element)
+			exampleRows[outline]++
+			methodName = methodName + " " + strconv.Itoa(exampleRows[outline])
+		}
+
+		testcase := &TestCase{ReportFileName: jsonFile, ClassName: feature.Name, MethodName: methodName, Result: getCucumberResult(hooks, steps)}
+		addCucumberStepDetails(testcase, element.Before)
+		addCucumberStepDetails(testcase, steps)
+		addCucumberStepDetails(testcase, element.After)
+		testcases = append(testcases, testcase)
+	}
+
+	if testcases == nil {
+		return ts
+	}
+
+	return append(ts, TestSuite{feature.Name, testcases})
+}
+
+func isCucumberScenarioOutline(element *CucumberElement) bool {
+	return element.Keyword == "Scenario Outline" || element.Keyword == "Scenario Template"
+}
+
+// Cucumber element IDs of example rows look like <feature>;<scenario outline>;<examples>;<row>
+func getCucumberOutlineID(element *CucumberElement) string {
+	parts := strings.Split(element.ID, ";")
+	if len(parts) < 2 {
+		return element.Name
+	}
+	return parts[1]
+}
+
+// Derive the scenario result from its hooks and steps. Failed, undefined, pending and ambiguous steps (or hooks)
+// fail the scenario. Otherwise only the steps decide, as hooks pass even if the steps are skipped: Like in Cucumber, a
+// scenario with a skipped step or without a single passed step counts as skipped
+func getCucumberResult(hooks []*CucumberStep, steps []*CucumberStep) int {
+	for _, hook := range hooks {
+		if hook.Result != nil && hook.Result.Status != "passed" && hook.Result.Status != "skipped" {
+			return FAILURE
+		}
+	}
+
+	var passed, skipped bool
+	for _, step := range steps {
+		if step.Result == nil {
+			continue
+		}
+		switch step.Result.Status {
+		case "passed":
+			passed = true
+		case "skipped":
+			skipped = true
+		default: // failed, undefined, pending, ambiguous
+			return FAILURE
+		}
+	}
+	if passed && !skipped {
+		return SUCCESS
+	}
+	return SKIPPED
+}
+
+// The duration of a scenario is the sum of its step durations (reported in nanoseconds). The error message of the
+// first failing step is kept
+func addCucumberStepDetails(testcase *TestCase, steps []*CucumberStep) {
+	for _, step := range steps {
+		if step.Result == nil {
+			continue
+		}
+		testcase.Duration += time.Duration(step.Result.Duration)
+		if step.Result.ErrorMessage != "" && testcase.StackTrace == "" {
+			testcase.Message = firstLine(step.Result.ErrorMessage)
+			testcase.StackTrace = strings.TrimSpace(step.Result.ErrorMessage)
+		}
+	}
+}
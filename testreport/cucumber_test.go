@@ -0,0 +1,144 @@
+package testreport
+
+import (
+	"testing"
+)
+
+func TestParseCucumberFile(t *testing.T) {
+	fp := "cucumber.json"
+
+	x := []byte(`[
+		{
+			"uri": "features/login.feature",
+			"id": "login",
+			"keyword": "Feature",
+			"name": "Login",
+			"elements": [
+				{
+					"id": "login;successful-login",
+					"keyword": "Scenario",
+					"name": "Successful login",
+					"type": "scenario",
+					"before": [{"result": {"status": "passed"}}],
+					"steps": [
+						{"keyword": "Given ", "name": "a registered user", "result": {"status": "passed"}},
+						{"keyword": "Then ", "name": "the user is logged in", "result": {"status": "passed"}}
+					]
+				},
+				{
+					"keyword": "Background",
+					"name": "",
+					"type": "background",
+					"steps": [{"keyword": "Given ", "name": "the login page", "result": {"status": "failed", "error_message": "page not found"}}]
+				},
+				{
+					"id": "login;login-with-background",
+					"keyword": "Scenario",
+					"name": "Login with background",
+					"type": "scenario",
+					"steps": [{"keyword": "Then ", "name": "the user is logged in", "result": {"status": "skipped"}}]
+				},
+				{
+					"id": "login;pending-login",
+					"keyword": "Scenario",
+					"name": "Pending login",
+					"type": "scenario",
+					"steps": [
+						{"keyword": "Given ", "name": "a registered user", "result": {"status": "passed"}},
+						{"keyword": "When ", "name": "the user does something new", "result": {"status": "pending"}}
+					]
+				},
+				{
+					"id": "login;undefined-login",
+					"keyword": "Scenario",
+					"name": "Undefined login",
+					"type": "scenario",
+					"steps": [{"keyword": "Given ", "name": "an undefined step", "result": {"status": "undefined"}}]
+				},
+				{
+					"id": "login;skipped-login",
+					"keyword": "Scenario",
+					"name": "Skipped login",
+					"type": "scenario",
+					"steps": [{"keyword": "Given ", "name": "a registered user", "result": {"status": "skipped"}}]
+				},
+				{
+					"id": "login;skipped-login-with-hooks",
+					"keyword": "Scenario",
+					"name": "Skipped login with hooks",
+					"type": "scenario",
+					"before": [{"result": {"status": "passed"}}],
+					"steps": [{"keyword": "Given ", "name": "a registered user", "result": {"status": "skipped"}}],
+					"after": [{"result": {"status": "passed"}}]
+				},
+				{
+					"id": "login;partly-skipped-login",
+					"keyword": "Scenario",
+					"name": "Partly skipped login",
+					"type": "scenario",
+					"steps": [
+						{"keyword": "Given ", "name": "a registered user", "result": {"status": "passed"}},
+						{"keyword": "Then ", "name": "the user is logged in", "result": {"status": "skipped"}}
+					]
+				},
+				{
+					"id": "login;login-with-failing-hook",
+					"keyword": "Scenario",
+					"name": "Login with failing hook",
+					"type": "scenario",
+					"steps": [{"keyword": "Given ", "name": "a registered user", "result": {"status": "passed"}}],
+					"after": [{"result": {"status": "failed", "error_message": "screenshot failed"}}]
+				},
+				{
+					"id": "login;login-with-roles;;2",
+					"keyword": "Scenario Outline",
+					"name": "Login with roles",
+					"type": "scenario",
+					"steps": [{"keyword": "Given ", "name": "an admin", "result": {"status": "passed"}}]
+				},
+				{
+					"id": "login;login-with-roles;;3",
+					"keyword": "Scenario Outline",
+					"name": "Login with roles",
+					"type": "scenario",
+					"steps": [{"keyword": "Given ", "name": "a guest", "result": {"status": "failed"}}]
+				}
+			]
+		}
+	]`)
+
+	var ts = []TestSuite{}
+	ts = parseCucumberFile(fp, x, ts)
+
+	if len(ts) != 1 {
+		t.Fatal("Should parse exactly one test suite")
+	}
+	if ts[0].Name != "Login" {
+		t.Error("Invalid test suite name was parsed")
+	}
+	checkTestCases(t, ts[0].TestCase, []expectedTestCase{
+		{"Login", "Successful login", SUCCESS},
+		{"Login", "Login with background", FAILURE},
+		{"Login", "Pending login", FAILURE},
+		{"Login", "Undefined login", FAILURE},
+		{"Login", "Skipped login", SKIPPED},
+		{"Login", "Skipped login with hooks", SKIPPED},
+		{"Login", "Partly skipped login", SKIPPED},
+		{"Login", "Login with failing hook", FAILURE},
+		{"Login", "Login with roles 1", SUCCESS},
+		{"Login", "Login with roles 2", FAILURE},
+	})
+}
+
+func TestParseCucumberFileIgnoresOtherJSON(t *testing.T) {
+	fp := "package.json"
+
+	x := []byte(`{"name": "my-app", "version": "1.0.0"}`)
+
+	var ts = []TestSuite{}
+	ts = parseCucumberFile(fp, x, ts)
+
+	if len(ts) != 0 {
+		t.Error("Should not parse a test suite from a non Cucumber JSON file")
+	}
+}
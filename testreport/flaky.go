@@ -0,0 +1,46 @@
+package testreport
+
+// MarkFlakyTestCases marks test cases as flaky, if the very same test case (class, method, variant and environment) is
+// part of multiple test report files with mixed outcomes (e.g. because the test job was retried and passed on the second
+// run). Test cases of the same report file (e.g. parameterized tests) aren't compared, only the n-th run in one report
+// file with the n-th run in the other report files. Neither are test cases of different environments compared, as a
+// test might fail in one environment only
+func MarkFlakyTestCases(testSuites []TestSuite) {
+	testCases, keys := groupTestCaseRuns(testSuites)
+
+	for _, key := range keys {
+		if !isFlaky(testCases[key]) {
+			continue
+		}
+		for _, tc := range testCases[key] {
+			if tc.Result == SUCCESS || tc.Result == FAILURE || tc.Result == ERROR {
+				tc.Result = FLAKY
+			}
+		}
+	}
+}
+
+// Test cases are flaky, if they passed in one report file, but failed (or ran into an error) in another one
+func isFlaky(testCases []*TestCase) bool {
+	var passedIn = make(map[string]bool)
+	var failedIn = make(map[string]bool)
+	for _, tc := range testCases {
+		switch tc.Result {
+		case SUCCESS, FLAKY:
+			passedIn[tc.ReportFileName] = true
+		case FAILURE, ERROR:
+			failedIn[tc.ReportFileName] = true
+		}
+	}
+	if len(passedIn) == 0 || len(failedIn) == 0 {
+		return false
+	}
+	for file := range passedIn {
+		for otherFile := range failedIn {
+			if file != otherFile {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package testreport
+
+import (
+	"testing"
+)
+
+func TestMarkFlakyTestCases(t *testing.T) {
+	ts := []TestSuite{
+		{Name: "MyTest", TestCase: []*TestCase{
+			{ReportFileName: "run1/TEST-MyTest.xml", ClassName: "MyTest", MethodName: "unstableTest", Result: FAILURE},
+			{ReportFileName: "run1/TEST-MyTest.xml", ClassName: "MyTest", MethodName: "stableTest", Result: SUCCESS},
+			{ReportFileName: "run1/TEST-MyTest.xml", ClassName: "MyTest", MethodName: "brokenTest", Result: FAILURE},
+			{ReportFileName: "run1/TEST-MyTest.xml", ClassName: "MyTest", MethodName: "paramTest", Result: SUCCESS},
+			{ReportFileName: "run1/TEST-MyTest.xml", ClassName: "MyTest", MethodName: "paramTest", Result: FAILURE},
+		}},
+		{Name: "MyTest", TestCase: []*TestCase{
+			{ReportFileName: "run2/TEST-MyTest.xml", ClassName: "MyTest", MethodName: "unstableTest", Result: SUCCESS},
+			{ReportFileName: "run2/TEST-MyTest.xml", ClassName: "MyTest", MethodName: "stableTest", Result: SUCCESS},
+			{ReportFileName: "run2/TEST-MyTest.xml", ClassName: "MyTest", MethodName: "brokenTest", Result: ERROR},
+		}},
+	}
+
+	MarkFlakyTestCases(ts)
+
+	checkTestCases(t, ts[0].TestCase, []expectedTestCase{
+		{"MyTest", "unstableTest", FLAKY},
+		{"MyTest", "stableTest", SUCCESS},
+		{"MyTest", "brokenTest", FAILURE},
+		{"MyTest", "paramTest", SUCCESS},
+		{"MyTest", "paramTest", FAILURE},
+	})
+	checkTestCases(t, ts[1].TestCase, []expectedTestCase{
+		{"MyTest", "unstableTest", FLAKY},
+		{"MyTest", "stableTest", SUCCESS},
+		{"MyTest", "brokenTest", ERROR},
+	})
+}
+
+func TestMarkFlakyParameterizedTestCases(t *testing.T) {
+	ts := []TestSuite{
+		{Name: "MyTest", TestCase: []*TestCase{
+			{ReportFileName: "run1/TEST-MyTest.xml", ClassName: "MyTest", MethodName: "paramTest", Result: SUCCESS},
+			{ReportFileName: "run1/TEST-MyTest.xml", ClassName: "MyTest", MethodName: "paramTest", Result: FAILURE},
+			{ReportFileName: "run1/TEST-MyTest.xml", ClassName: "MyTest", MethodName: "unstableParamTest", Result: SUCCESS},
+			{ReportFileName: "run1/TEST-MyTest.xml", ClassName: "MyTest", MethodName: "unstableParamTest", Result: FAILURE},
+		}},
+		{Name: "MyTest", TestCase: []*TestCase{
+			{ReportFileName: "run2/TEST-MyTest.xml", ClassName: "MyTest", MethodName: "paramTest", Result: SUCCESS},
+			{ReportFileName: "run2/TEST-MyTest.xml", ClassName: "MyTest", MethodName: "paramTest", Result: FAILURE},
+			{ReportFileName: "run2/TEST-MyTest.xml", ClassName: "MyTest", MethodName: "unstableParamTest", Result: SUCCESS},
+			{ReportFileName: "run2/TEST-MyTest.xml", ClassName: "MyTest", MethodName: "unstableParamTest", Result: SUCCESS},
+		}},
+	}
+
+	MarkFlakyTestCases(ts)
+
+	checkTestCases(t, ts[0].TestCase, []expectedTestCase{
+		{"MyTest", "paramTest", SUCCESS},
+		{"MyTest", "paramTest", FAILURE},
+		{"MyTest", "unstableParamTest", SUCCESS},
+		{"MyTest", "unstableParamTest", FLAKY},
+	})
+	checkTestCases(t, ts[1].TestCase, []expectedTestCase{
+		{"MyTest", "paramTest", SUCCESS},
+		{"MyTest", "paramTest", FAILURE},
+		{"MyTest", "unstableParamTest", SUCCESS},
+		{"MyTest", "unstableParamTest", FLAKY},
+	})
+}
+
+func TestMarkFlakyTestCasesPerEnvironment(t *testing.T) {
+	ts := []TestSuite{
+		{Name: "MyTest", TestCase: []*TestCase{
+			{ReportFileName: "linux/TEST-MyTest.xml", ClassName: "MyTest", MethodName: "windowsOnlyFailure", Result: SUCCESS, Environment: "linux"},
+		}},
+		{Name: "MyTest", TestCase: []*TestCase{
+			{ReportFileName: "windows/TEST-MyTest.xml", ClassName: "MyTest", MethodName: "windowsOnlyFailure", Result: FAILURE, Environment: "windows"},
+		}},
+	}
+
+	MarkFlakyTestCases(ts)
+
+	checkTestCases(t, ts[0].TestCase, []expectedTestCase{{"MyTest", "windowsOnlyFailure", SUCCESS}})
+	checkTestCases(t, ts[1].TestCase, []expectedTestCase{{"MyTest", "windowsOnlyFailure", FAILURE}})
+}
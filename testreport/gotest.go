@@ -0,0 +1,122 @@
+package testreport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/SAP/quality-continuous-traceability-monitor/utils"
+	"github.com/golang/glog"
+)
+
+// GoTestEvent go test -json event struct (see go doc test2json)
+type GoTestEvent struct {
+	Time    time.Time `json:",omitempty"`
+	Action  string
+	Package string  `json:",omitempty"`
+	Test    string  `json:",omitempty"`
+	Elapsed float64 `json:",omitempty"`
+	Output  string  `json:",omitempty"`
+}
+
+// GoTestReport go test -json test report structure
+type GoTestReport struct {
+}
+
+// Parse go test -json test result reports
+func (gotr *GoTestReport) Parse(reportRootPath string) []TestSuite {
+	defer utils.TimeTrack(time.Now(), "Scan go test JSON test reports")
+
+	return parseReportFiles(reportRootPath, func(path string) bool {
+		return hasExtension(path, ".json", ".jsonl")
+	}, readContent(parseGoTestFile))
+}
+
+// go test -json writes one event per line. We rebuild the final result of each test from its events.
+// Each package becomes a TestSuite, the package import path is used as class name and the test name as method name.
+// Subtests (e.g. TestX/case_1) become test cases on their own. As go fails a parent test if one of its subtests fails,
+// the parent test (e.g. TestX) carries the rolled up result of all its subtests
+func parseGoTestFile(jsonFilePath string, jfb []byte, ts []TestSuite) []TestSuite {
+	var suites []TestSuite
+	var suiteIndex = make(map[string]int)
+	var testcases = make(map[string]*TestCase)
+	var output = make(map[string]*strings.Builder)
+
+	reader := bufio.NewReader(bytes.NewReader(jfb))
+	for {
+		line, err := reader.ReadBytes('\n')
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 && line[0] == '{' {
+			var event GoTestEvent
+			if json.Unmarshal(line, &event) == nil && event.Package != "" && event.Test != "" {
+				id := event.Package + " " + event.Test
+				testcase, found := testcases[id]
+				if !found {
+					// A test without any final event (e.g. because the test binary panicked or timed out) failed
+					testcase = &TestCase{ReportFileName: jsonFilePath, ClassName: event.Package, MethodName: event.Test, Result: FAILURE, Timestamp: event.Time}
+					testcases[id] = testcase
+					output[id] = &strings.Builder{}
+
+					i, found := suiteIndex[event.Package]
+					if !found {
+						i = len(suites)
+						suiteIndex[event.Package] = i
+						suites = append(suites, TestSuite{Name: event.Package})
+					}
+					suites[i].TestCase = append(suites[i].TestCase, testcase)
+				}
+				switch event.Action {
+				case "pass":
+					testcase.Result = SUCCESS
+				case "fail":
+					testcase.Result = FAILURE
+				case "skip":
+					testcase.Result = SKIPPED
+				case "output":
+					output[id].WriteString(event.Output)
+				}
+				if event.Elapsed > 0 {
+					testcase.Duration = time.Duration(event.Elapsed * float64(time.Second))
+				}
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			glog.Error("Unable to read go test JSON file ", jsonFilePath, ": ", err)
+			break
+		}
+	}
+
+	if suites == nil {
+		glog.Info("No test cases found in ", jsonFilePath)
+		return ts
+	}
+
+	// go test doesn't distinguish between failure message and output. Failed tests get the last output line as message
+	for id, testcase := range testcases {
+		testcase.Stdout = truncateOutput(output[id].String())
+		if testcase.Result == FAILURE {
+			testcase.Message = lastLine(testcase.Stdout)
+		}
+	}
+
+	return append(ts, suites...)
+}
+
+// The last line of the go test output of a test is the --- FAIL: line. The one before usually holds the failure reason
+func lastLine(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if line != "" && !strings.HasPrefix(line, "--- FAIL") && !strings.HasPrefix(line, "=== ") {
+			return line
+		}
+	}
+	return ""
+}
@@ -0,0 +1,75 @@
+package testreport
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseGoTestFile(t *testing.T) {
+	fp := "go-test.json"
+
+	x := []byte(`{"Time":"2019-11-05T10:26:10.1Z","Action":"start","Package":"github.com/myorg/myapp/calc"}
+{"Time":"2019-11-05T10:26:10.1Z","Action":"run","Package":"github.com/myorg/myapp/calc","Test":"TestAdd"}
+{"Time":"2019-11-05T10:26:10.1Z","Action":"output","Package":"github.com/myorg/myapp/calc","Test":"TestAdd","Output":"=== RUN   TestAdd\n"}
+{"Time":"2019-11-05T10:26:10.1Z","Action":"pass","Package":"github.com/myorg/myapp/calc","Test":"TestAdd","Elapsed":0}
+{"Time":"2019-11-05T10:26:10.1Z","Action":"run","Package":"github.com/myorg/myapp/calc","Test":"TestDivide"}
+{"Time":"2019-11-05T10:26:10.1Z","Action":"run","Package":"github.com/myorg/myapp/calc","Test":"TestDivide/by_one"}
+{"Time":"2019-11-05T10:26:10.1Z","Action":"pause","Package":"github.com/myorg/myapp/calc","Test":"TestDivide/by_one"}
+{"Time":"2019-11-05T10:26:10.1Z","Action":"run","Package":"github.com/myorg/myapp/calc","Test":"TestDivide/by_zero"}
+{"Time":"2019-11-05T10:26:10.1Z","Action":"output","Package":"github.com/myorg/myapp/calc","Test":"TestDivide/by_zero","Output":"    calc_test.go:12: division by zero\n"}
+{"Time":"2019-11-05T10:26:10.1Z","Action":"fail","Package":"github.com/myorg/myapp/calc","Test":"TestDivide/by_zero","Elapsed":0}
+{"Time":"2019-11-05T10:26:10.1Z","Action":"cont","Package":"github.com/myorg/myapp/calc","Test":"TestDivide/by_one"}
+{"Time":"2019-11-05T10:26:10.1Z","Action":"pass","Package":"github.com/myorg/myapp/calc","Test":"TestDivide/by_one","Elapsed":0}
+{"Time":"2019-11-05T10:26:10.1Z","Action":"fail","Package":"github.com/myorg/myapp/calc","Test":"TestDivide","Elapsed":0}
+{"Time":"2019-11-05T10:26:10.1Z","Action":"run","Package":"github.com/myorg/myapp/calc","Test":"TestSubtract"}
+{"Time":"2019-11-05T10:26:10.1Z","Action":"skip","Package":"github.com/myorg/myapp/calc","Test":"TestSubtract","Elapsed":0}
+{"Time":"2019-11-05T10:26:10.1Z","Action":"fail","Package":"github.com/myorg/myapp/calc","Elapsed":0.01}
+# github.com/myorg/myapp/util [build output]
+{"Time":"2019-11-05T10:26:10.2Z","Action":"run","Package":"github.com/myorg/myapp/util","Test":"TestTimeout"}
+{"Time":"2019-11-05T10:26:10.2Z","Action":"output","Package":"github.com/myorg/myapp/util","Test":"TestTimeout","Output":"panic: test timed out after 10m0s\n"}
+{"Time":"2019-11-05T10:26:10.2Z","Action":"fail","Package":"github.com/myorg/myapp/util","Elapsed":600}
+`)
+
+	var ts = []TestSuite{}
+	ts = parseGoTestFile(fp, x, ts)
+
+	if len(ts) != 2 {
+		t.Fatal("Should parse exactly two test suites (one per package)")
+	}
+	if ts[0].Name != "github.com/myorg/myapp/calc" || ts[1].Name != "github.com/myorg/myapp/util" {
+		t.Error("Invalid test suite names were parsed")
+	}
+	checkTestCases(t, ts[0].TestCase, []expectedTestCase{
+		{"github.com/myorg/myapp/calc", "TestAdd", SUCCESS},
+		{"github.com/myorg/myapp/calc", "TestDivide", FAILURE},
+		{"github.com/myorg/myapp/calc", "TestDivide/by_one", SUCCESS},
+		{"github.com/myorg/myapp/calc", "TestDivide/by_zero", FAILURE},
+		{"github.com/myorg/myapp/calc", "TestSubtract", SKIPPED},
+	})
+	checkTestCases(t, ts[1].TestCase, []expectedTestCase{
+		{"github.com/myorg/myapp/util", "TestTimeout", FAILURE},
+	})
+
+	if msg := ts[0].TestCase[3].Message; msg != "calc_test.go:12: division by zero" {
+		t.Error("Invalid failure message was parsed: ", msg)
+	}
+	if msg := ts[1].TestCase[0].Message; msg != "panic: test timed out after 10m0s" {
+		t.Error("Invalid failure message was parsed: ", msg)
+	}
+	if timestamp := ts[1].TestCase[0].Timestamp; !timestamp.Equal(time.Date(2019, 11, 5, 10, 26, 10, 200000000, time.UTC)) {
+		t.Error("Invalid start time was parsed: ", timestamp)
+	}
+}
+
+func TestParseGoTestFileIgnoresOtherJSON(t *testing.T) {
+	fp := "package.json"
+
+	x := []byte(`{"name": "my-app", "version": "1.0.0"}`)
+
+	var ts = []TestSuite{}
+	ts = parseGoTestFile(fp, x, ts)
+
+	if len(ts) != 0 {
+		t.Error("Should not parse a test suite from a non go test JSON file")
+	}
+}
@@ -0,0 +1,103 @@
+package testreport
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/SAP/quality-continuous-traceability-monitor/utils"
+	"github.com/golang/glog"
+)
+
+// JestAssertionResult Jest assertion result (one test) structure
+type JestAssertionResult struct {
+	AncestorTitles  []string `json:"ancestorTitles"`
+	Title           string   `json:"title"`
+	FullName        string   `json:"fullName"`
+	Status          string   `json:"status"`
+	Duration        *float64 `json:"duration,omitempty"`
+	FailureMessages []string `json:"failureMessages,omitempty"`
+}
+
+// JestTestResult Jest test result (one test file) structure
+type JestTestResult struct {
+	Name             string                 `json:"name"`
+	Status           string                 `json:"status"`
+	Message          string                 `json:"message,omitempty"`
+	StartTime        int64                  `json:"startTime,omitempty"`
+	EndTime          int64                  `json:"endTime,omitempty"`
+	AssertionResults []*JestAssertionResult `json:"assertionResults"`
+}
+
+// JestResults Jest (jest --json) results structure
+type JestResults struct {
+	NumTotalTests int               `json:"numTotalTests"`
+	Success       bool              `json:"success"`
+	TestResults   []*JestTestResult `json:"testResults"`
+}
+
+// JestTestReport Jest JSON test report structure
+type JestTestReport struct {
+}
+
+// Parse Jest JSON test result reports (jest --json)
+func (jtr *JestTestReport) Parse(reportRootPath string) []TestSuite {
+	defer utils.TimeTrack(time.Now(), "Scan Jest JSON test reports")
+
+	return parseReportFiles(reportRootPath, func(path string) bool {
+		return hasExtension(path, ".json")
+	}, readContent(parseJestFile))
+}
+
+func parseJestFile(jsonFilePath string, jfb []byte, ts []TestSuite) []TestSuite {
+	var jestResults JestResults
+	err := json.Unmarshal(jfb, &jestResults)
+	if err != nil || jestResults.TestResults == nil {
+		glog.Info("Not a Jest JSON file ", jsonFilePath, ": ", err)
+		return ts
+	}
+
+	for _, testResult := range jestResults.TestResults {
+		ts = addJestTestResultToTestResult(jsonFilePath, ts, *testResult)
+	}
+
+	return ts
+}
+
+// Map the Jest test result of one test file to the common (generalized) TestSuite struct.
+// Just like the JSParser does, the titles of the (nested) describe blocks are joined with spaces into the
+// class name and the it title is used as method name
+func addJestTestResultToTestResult(jsonFile string, ts []TestSuite, testResult JestTestResult) []TestSuite {
+	var testcases []*TestCase
+	for _, assertionResult := range testResult.AssertionResults {
+		className := strings.Join(assertionResult.AncestorTitles, " ")
+		testcase := &TestCase{ReportFileName: jsonFile, ClassName: className, MethodName: assertionResult.Title, Result: getJestResult(assertionResult.Status)}
+		if assertionResult.Duration != nil {
+			testcase.Duration = parseMilliseconds(*assertionResult.Duration)
+		}
+		if assertionResult.FailureMessages != nil {
+			testcase.StackTrace = strings.TrimSpace(strings.Join(assertionResult.FailureMessages, "\n"))
+			testcase.Message = firstLine(testcase.StackTrace)
+		}
+		testcases = append(testcases, testcase)
+	}
+
+	if testcases == nil {
+		glog.Info("No test cases found for ", testResult.Name, " in ", jsonFile)
+		return ts
+	}
+
+	return append(ts, TestSuite{testResult.Name, testcases})
+}
+
+// Map Jest test status (passed, failed, pending, skipped, todo, disabled) to our test result
+func getJestResult(status string) int {
+	switch status {
+	case "passed":
+		return SUCCESS
+	case "pending", "skipped", "todo", "disabled":
+		return SKIPPED
+	default:
+		return FAILURE
+	}
+}
@@ -0,0 +1,56 @@
+package testreport
+
+import (
+	"testing"
+)
+
+func TestParseJestFile(t *testing.T) {
+	fp := "jest-results.json"
+
+	x := []byte(`{
+		"numFailedTests": 1,
+		"numTotalTests": 4,
+		"success": false,
+		"testResults": [
+			{
+				"name": "/tmp/myapp/test/login.test.js",
+				"status": "failed",
+				"assertionResults": [
+					{"ancestorTitles": ["Login", "with valid user"], "title": "logs in", "fullName": "Login with valid user logs in", "status": "passed", "duration": 3, "failureMessages": []},
+					{"ancestorTitles": ["Login", "with invalid user"], "title": "shows an error", "fullName": "Login with invalid user shows an error", "status": "failed", "failureMessages": ["expected error"]},
+					{"ancestorTitles": ["Login"], "title": "remembers the user", "fullName": "Login remembers the user", "status": "pending"},
+					{"ancestorTitles": ["Login"], "title": "resets the password", "fullName": "Login resets the password", "status": "todo"}
+				]
+			}
+		]
+	}`)
+
+	var ts = []TestSuite{}
+	ts = parseJestFile(fp, x, ts)
+
+	if len(ts) != 1 {
+		t.Fatal("Should parse exactly one test suite")
+	}
+	if ts[0].Name != "/tmp/myapp/test/login.test.js" {
+		t.Error("Invalid test suite name was parsed")
+	}
+	checkTestCases(t, ts[0].TestCase, []expectedTestCase{
+		{"Login with valid user", "logs in", SUCCESS},
+		{"Login with invalid user", "shows an error", FAILURE},
+		{"Login", "remembers the user", SKIPPED},
+		{"Login", "resets the password", SKIPPED},
+	})
+}
+
+func TestParseJestFileIgnoresOtherJSON(t *testing.T) {
+	fp := "package.json"
+
+	x := []byte(`{"name": "my-app", "version": "1.0.0"}`)
+
+	var ts = []TestSuite{}
+	ts = parseJestFile(fp, x, ts)
+
+	if len(ts) != 0 {
+		t.Error("Should not parse a test suite from a non Jest JSON file")
+	}
+}
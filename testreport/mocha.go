@@ -0,0 +1,109 @@
+package testreport
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/SAP/quality-continuous-traceability-monitor/utils"
+	"github.com/golang/glog"
+)
+
+// MochaError Mocha test error struct
+type MochaError struct {
+	Message string `json:"message,omitempty"`
+	Stack   string `json:"stack,omitempty"`
+}
+
+// MochaTest Mocha test structure
+type MochaTest struct {
+	Title        string      `json:"title"`
+	FullTitle    string      `json:"fullTitle"`
+	File         string      `json:"file,omitempty"`
+	Duration     *float64    `json:"duration,omitempty"`
+	CurrentRetry int         `json:"currentRetry,omitempty"`
+	Err          *MochaError `json:"err,omitempty"`
+}
+
+// MochaStats Mocha statistics struct
+type MochaStats struct {
+	Suites   int `json:"suites"`
+	Tests    int `json:"tests"`
+	Passes   int `json:"passes"`
+	Pending  int `json:"pending"`
+	Failures int `json:"failures"`
+}
+
+// MochaResults Mocha (json reporter) results structure
+type MochaResults struct {
+	Stats    *MochaStats  `json:"stats"`
+	Passes   []*MochaTest `json:"passes"`
+	Failures []*MochaTest `json:"failures"`
+	Pending  []*MochaTest `json:"pending"`
+}
+
+// MochaTestReport Mocha JSON test report structure
+type MochaTestReport struct {
+}
+
+// Parse Mocha JSON test result reports (mocha --reporter json)
+func (mtr *MochaTestReport) Parse(reportRootPath string) []TestSuite {
+	defer utils.TimeTrack(time.Now(), "Scan Mocha JSON test reports")
+
+	return parseReportFiles(reportRootPath, func(path string) bool {
+		return hasExtension(path, ".json")
+	}, readContent(parseMochaFile))
+}
+
+func parseMochaFile(jsonFilePath string, jfb []byte, ts []TestSuite) []TestSuite {
+	var mochaResults MochaResults
+	err := json.Unmarshal(jfb, &mochaResults)
+	if err != nil || mochaResults.Stats == nil {
+		glog.Info("Not a Mocha JSON file ", jsonFilePath, ": ", err)
+		return ts
+	}
+
+	var suites []TestSuite
+	var suiteIndex = make(map[string]int)
+	addTests := func(tests []*MochaTest, result int) {
+		for _, test := range tests {
+			testcase := &TestCase{ReportFileName: jsonFilePath, ClassName: getMochaClassName(test), MethodName: test.Title, Result: result}
+			if test.Duration != nil {
+				testcase.Duration = parseMilliseconds(*test.Duration)
+			}
+			if test.Err != nil {
+				testcase.Message = test.Err.Message
+				testcase.StackTrace = strings.TrimSpace(test.Err.Stack)
+			}
+
+			// Group the test cases by test file (if known) or by class name
+			suiteName := test.File
+			if suiteName == "" {
+				suiteName = testcase.ClassName
+			}
+			i, found := suiteIndex[suiteName]
+			if !found {
+				i = len(suites)
+				suiteIndex[suiteName] = i
+				suites = append(suites, TestSuite{Name: suiteName})
+			}
+			suites[i].TestCase = append(suites[i].TestCase, testcase)
+		}
+	}
+	addTests(mochaResults.Passes, SUCCESS)
+	addTests(mochaResults.Failures, FAILURE)
+	addTests(mochaResults.Pending, SKIPPED)
+
+	if suites == nil {
+		glog.Info("No test cases found in ", jsonFilePath)
+		return ts
+	}
+
+	return append(ts, suites...)
+}
+
+// Mocha only reports the full title, which are the titles of all (nested) describe blocks and the it title joined
+// with spaces. Just like the JSParser does, we're using the describe titles as class name
+func getMochaClassName(test *MochaTest) string {
+	return strings.TrimSpace(strings.TrimSuffix(test.FullTitle, test.Title))
+}
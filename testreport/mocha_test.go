@@ -0,0 +1,55 @@
+package testreport
+
+import (
+	"testing"
+)
+
+func TestParseMochaFile(t *testing.T) {
+	fp := "mocha-results.json"
+
+	x := []byte(`{
+		"stats": {"suites": 3, "tests": 3, "passes": 1, "pending": 1, "failures": 1},
+		"tests": [],
+		"pending": [
+			{"title": "remembers the user", "fullTitle": "Login remembers the user", "file": "/tmp/myapp/test/login.js", "currentRetry": 0, "err": {}}
+		],
+		"failures": [
+			{"title": "shows an error", "fullTitle": "Login with invalid user shows an error", "file": "/tmp/myapp/test/login.js", "duration": 2, "currentRetry": 0, "err": {"message": "expected error", "stack": "AssertionError: expected error"}}
+		],
+		"passes": [
+			{"title": "logs in", "fullTitle": "Login with valid user logs in", "file": "/tmp/myapp/test/login.js", "duration": 3, "currentRetry": 0, "err": {}},
+			{"title": "logs out", "fullTitle": "Logout logs out", "duration": 1, "currentRetry": 0, "err": {}}
+		]
+	}`)
+
+	var ts = []TestSuite{}
+	ts = parseMochaFile(fp, x, ts)
+
+	if len(ts) != 2 {
+		t.Fatal("Should parse exactly two test suites")
+	}
+	if ts[0].Name != "/tmp/myapp/test/login.js" || ts[1].Name != "Logout" {
+		t.Error("Invalid test suite names were parsed")
+	}
+	checkTestCases(t, ts[0].TestCase, []expectedTestCase{
+		{"Login with valid user", "logs in", SUCCESS},
+		{"Login with invalid user", "shows an error", FAILURE},
+		{"Login", "remembers the user", SKIPPED},
+	})
+	checkTestCases(t, ts[1].TestCase, []expectedTestCase{
+		{"Logout", "logs out", SUCCESS},
+	})
+}
+
+func TestParseMochaFileIgnoresOtherJSON(t *testing.T) {
+	fp := "package.json"
+
+	x := []byte(`{"name": "my-app", "version": "1.0.0"}`)
+
+	var ts = []TestSuite{}
+	ts = parseMochaFile(fp, x, ts)
+
+	if len(ts) != 0 {
+		t.Error("Should not parse a test suite from a non Mocha JSON file")
+	}
+}
@@ -0,0 +1,136 @@
+package testreport
+
+import (
+	"encoding/xml"
+	"strings"
+	"time"
+
+	"github.com/SAP/quality-continuous-traceability-monitor/utils"
+	"github.com/golang/glog"
+)
+
+// NUFailure NUnit failure struct
+type NUFailure struct {
+	Message    string `xml:"message"`
+	StackTrace string `xml:"stack-trace"`
+}
+
+// NUReason NUnit reason struct (e.g. why a test was skipped)
+type NUReason struct {
+	Message string `xml:"message"`
+}
+
+// NUTestCase NUnit test case structure
+type NUTestCase struct {
+	ID         string     `xml:"id,attr"`
+	Name       string     `xml:"name,attr"`
+	FullName   string     `xml:"fullname,attr"`
+	MethodName string     `xml:"methodname,attr"`
+	ClassName  string     `xml:"classname,attr"`
+	Result     string     `xml:"result,attr"`
+	Label      string     `xml:"label,attr,omitempty"`
+	Duration   string     `xml:"duration,attr,omitempty"`
+	Failure    *NUFailure `xml:"failure,omitempty"`
+	Reason     *NUReason  `xml:"reason,omitempty"`
+	Output     string     `xml:"output,omitempty"`
+}
+
+// NUTestSuite NUnit test suite structure. Test suites are nested (Assembly -> TestSuite (namespace) -> TestFixture -> ParameterizedMethod)
+type NUTestSuite struct {
+	Type      string         `xml:"type,attr"`
+	Name      string         `xml:"name,attr"`
+	FullName  string         `xml:"fullname,attr"`
+	ClassName string         `xml:"classname,attr,omitempty"`
+	Result    string         `xml:"result,attr"`
+	TestSuite []*NUTestSuite `xml:"test-suite,omitempty"`
+	TestCase  []*NUTestCase  `xml:"test-case,omitempty"`
+}
+
+// NUTestRun NUnit 3 test run (root element of TestResult.xml) structure
+type NUTestRun struct {
+	XMLName       xml.Name       `xml:"test-run"`
+	TestCaseCount string         `xml:"testcasecount,attr"`
+	Result        string         `xml:"result,attr"`
+	TestSuite     []*NUTestSuite `xml:"test-suite,omitempty"`
+}
+
+// NUTestReport NUnit 3 test report structure
+type NUTestReport struct {
+}
+
+// Parse NUnit 3 XML test result reports (TestResult.xml)
+func (nutr *NUTestReport) Parse(reportRootPath string) []TestSuite {
+	defer utils.TimeTrack(time.Now(), "Scan NUnit XML test reports")
+
+	return parseReportFiles(reportRootPath, func(path string) bool {
+		return hasExtension(path, ".xml")
+	}, readContent(parseNUnitFile))
+}
+
+func parseNUnitFile(xmlFilePath string, xfb []byte, ts []TestSuite) []TestSuite {
+	var nuTestRun NUTestRun
+	err := xml.Unmarshal(xfb, &nuTestRun)
+	if err != nil {
+		glog.Info("Not a NUnit 3 result file ", xmlFilePath, ": ", err)
+		return ts
+	}
+
+	var found = len(ts)
+	for _, nuTestSuite := range nuTestRun.TestSuite {
+		ts = addNUTestSuiteToTestResult(xmlFilePath, ts, *nuTestSuite)
+	}
+	if found == len(ts) {
+		glog.Info("No test cases found in ", xmlFilePath)
+	}
+
+	return ts
+}
+
+// Map the NUnit specific test suite (and recursively all its nested test suites) to the common (generalized)
+// TestSuite struct. Each NUnit test suite which directly contains test cases becomes one TestSuite
+func addNUTestSuiteToTestResult(xmlFile string, ts []TestSuite, nuts NUTestSuite) []TestSuite {
+	var testcases []*TestCase
+	for _, nutestcase := range nuts.TestCase {
+		// Parameterized tests have the parameters in the name (e.g. MyTest(1,2)). Methodname is the plain method name
+		methodName := nutestcase.MethodName
+		if methodName == "" {
+			methodName = nutestcase.Name
+		}
+		className := nutestcase.ClassName
+		if className == "" {
+			className = nuts.ClassName
+		}
+		testcase := &TestCase{ReportFileName: xmlFile, ClassName: className, MethodName: methodName, Result: getNUResult(nutestcase.Result, nutestcase.Label), Duration: parseSeconds(nutestcase.Duration), Stdout: truncateOutput(nutestcase.Output)}
+		if nutestcase.Failure != nil {
+			testcase.Message = strings.TrimSpace(nutestcase.Failure.Message)
+			testcase.StackTrace = strings.TrimSpace(nutestcase.Failure.StackTrace)
+		} else if nutestcase.Reason != nil {
+			testcase.Message = strings.TrimSpace(nutestcase.Reason.Message)
+		}
+		testcases = append(testcases, testcase)
+	}
+	if testcases != nil {
+		ts = append(ts, TestSuite{nuts.FullName, testcases})
+	}
+
+	for _, nuChildSuite := range nuts.TestSuite {
+		ts = addNUTestSuiteToTestResult(xmlFile, ts, *nuChildSuite)
+	}
+
+	return ts
+}
+
+// Map NUnit test case result (Passed, Failed, Skipped, Inconclusive) to our test result
+func getNUResult(result string, label string) int {
+	switch result {
+	case "Passed":
+		return SUCCESS
+	case "Skipped", "Inconclusive": // Inconclusive tests neither passed nor failed, they didn't really run
+		return SKIPPED
+	}
+	// Failed tests are labeled Error for unexpected exceptions and Invalid if they couldn't be run at all
+	if label == "Error" || label == "Invalid" {
+		return ERROR
+	}
+	return FAILURE
+}
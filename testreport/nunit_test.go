@@ -0,0 +1,89 @@
+package testreport
+
+import (
+	"testing"
+)
+
+func TestParseNUnitFile(t *testing.T) {
+	fp := "TestResult.xml"
+
+	x := []byte(`
+		<test-run id="2" testcasecount="5" result="Failed" total="5" passed="2" failed="1" inconclusive="1" skipped="1">
+			<test-suite type="Assembly" id="1-1005" name="MyApp.Tests.dll" fullname="/tmp/MyApp.Tests.dll" result="Failed">
+				<test-suite type="TestSuite" id="1-1006" name="MyApp" fullname="MyApp" result="Failed">
+					<test-suite type="TestSuite" id="1-1007" name="Tests" fullname="MyApp.Tests" result="Failed">
+						<test-suite type="TestFixture" id="1-1000" name="CalculatorTests" fullname="MyApp.Tests.CalculatorTests" classname="MyApp.Tests.CalculatorTests" result="Failed">
+							<test-case id="1-1001" name="Add" fullname="MyApp.Tests.CalculatorTests.Add" methodname="Add" classname="MyApp.Tests.CalculatorTests" result="Passed" duration="0.012"/>
+							<test-case id="1-1002" name="Divide" fullname="MyApp.Tests.CalculatorTests.Divide" methodname="Divide" classname="MyApp.Tests.CalculatorTests" result="Failed" duration="0.020">
+								<failure>
+									<message><![CDATA[Expected: 2 But was: 3]]></message>
+									<stack-trace><![CDATA[at MyApp.Tests.CalculatorTests.Divide()]]></stack-trace>
+								</failure>
+							</test-case>
+							<test-case id="1-1003" name="Subtract" fullname="MyApp.Tests.CalculatorTests.Subtract" methodname="Subtract" classname="MyApp.Tests.CalculatorTests" result="Skipped" label="Ignored"/>
+							<test-case id="1-1004" name="Multiply" fullname="MyApp.Tests.CalculatorTests.Multiply" methodname="Multiply" classname="MyApp.Tests.CalculatorTests" result="Inconclusive"/>
+							<test-suite type="ParameterizedMethod" id="1-1010" name="Square" fullname="MyApp.Tests.CalculatorTests.Square" classname="MyApp.Tests.CalculatorTests" result="Passed">
+								<test-case id="1-1011" name="Square(2)" fullname="MyApp.Tests.CalculatorTests.Square(2)" methodname="Square" classname="MyApp.Tests.CalculatorTests" result="Passed"/>
+							</test-suite>
+						</test-suite>
+					</test-suite>
+				</test-suite>
+			</test-suite>
+		</test-run>
+	`)
+
+	var ts = []TestSuite{}
+	ts = parseNUnitFile(fp, x, ts)
+
+	if len(ts) != 2 {
+		t.Fatal("Should parse exactly two test suites (test fixture and parameterized method)")
+	}
+	if ts[0].Name != "MyApp.Tests.CalculatorTests" || ts[1].Name != "MyApp.Tests.CalculatorTests.Square" {
+		t.Error("Invalid test suite names were parsed")
+	}
+	checkTestCases(t, ts[0].TestCase, []expectedTestCase{
+		{"MyApp.Tests.CalculatorTests", "Add", SUCCESS},
+		{"MyApp.Tests.CalculatorTests", "Divide", FAILURE},
+		{"MyApp.Tests.CalculatorTests", "Subtract", SKIPPED},
+		{"MyApp.Tests.CalculatorTests", "Multiply", SKIPPED},
+	})
+	checkTestCases(t, ts[1].TestCase, []expectedTestCase{
+		{"MyApp.Tests.CalculatorTests", "Square", SUCCESS},
+	})
+}
+
+func TestParseNUnitFileIgnoresOtherXML(t *testing.T) {
+	fp := "test_path.xml"
+
+	x := []byte(`
+		<testsuite name="MyApp.Tests.CalculatorTests" tests="1" errors="0" failures="0">
+			<testcase name="Add" classname="MyApp.Tests.CalculatorTests"/>
+		</testsuite>
+	`)
+
+	var ts = []TestSuite{}
+	ts = parseNUnitFile(fp, x, ts)
+
+	if len(ts) != 0 {
+		t.Error("Should not parse a test suite from a non NUnit XML file")
+	}
+}
+
+func TestGetNUResult(t *testing.T) {
+	tests := []struct {
+		result, label string
+		expected      int
+	}{
+		{"Passed", "", SUCCESS},
+		{"Failed", "", FAILURE},
+		{"Failed", "Error", ERROR},
+		{"Failed", "Invalid", ERROR},
+		{"Skipped", "Ignored", SKIPPED},
+		{"Inconclusive", "", SKIPPED},
+	}
+	for _, test := range tests {
+		if actual := getNUResult(test.result, test.label); actual != test.expected {
+			t.Errorf("Result %s (label %s) should be mapped to %d, got %d", test.result, test.label, test.expected, actual)
+		}
+	}
+}
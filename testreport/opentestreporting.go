@@ -0,0 +1,238 @@
+package testreport
+
+import (
+	"encoding/xml"
+	"strings"
+	"time"
+
+	"github.com/SAP/quality-continuous-traceability-monitor/utils"
+	"github.com/golang/glog"
+)
+
+// OTRMetadata JUnit Platform specific metadata of an Open Test Reporting started event
+type OTRMetadata struct {
+	UniqueID            string `xml:"uniqueId,omitempty"`
+	LegacyReportingName string `xml:"legacyReportingName,omitempty"`
+	Type                string `xml:"type,omitempty"` // CONTAINER, TEST or CONTAINER_AND_TEST
+}
+
+// OTRJavaSource Open Test Reporting java class/method source struct
+type OTRJavaSource struct {
+	ClassName            string `xml:"className,attr"`
+	MethodName           string `xml:"methodName,attr,omitempty"`
+	MethodParameterTypes string `xml:"methodParameterTypes,attr,omitempty"`
+}
+
+// OTRSources Open Test Reporting sources structure
+type OTRSources struct {
+	ClassSource  *OTRJavaSource `xml:"classSource,omitempty"`
+	MethodSource *OTRJavaSource `xml:"methodSource,omitempty"`
+}
+
+// OTRStarted Open Test Reporting started event structure
+type OTRStarted struct {
+	ID       string       `xml:"id,attr"`
+	ParentID string       `xml:"parentId,attr,omitempty"`
+	Name     string       `xml:"name,attr"`
+	Time     string       `xml:"time,attr,omitempty"`
+	Metadata *OTRMetadata `xml:"metadata,omitempty"`
+	Sources  *OTRSources  `xml:"sources,omitempty"`
+	Tags     []string     `xml:"tags>tag,omitempty"`
+}
+
+// OTRResult Open Test Reporting result struct
+type OTRResult struct {
+	Status    string `xml:"status,attr"`
+	Reason    string `xml:"reason,omitempty"`
+	Throwable string `xml:"throwable,omitempty"`
+}
+
+// OTRFinished Open Test Reporting finished event structure
+type OTRFinished struct {
+	ID     string     `xml:"id,attr"`
+	Time   string     `xml:"time,attr,omitempty"`
+	Result *OTRResult `xml:"result,omitempty"`
+}
+
+// OTREvents Open Test Reporting event based XML (root element) structure
+type OTREvents struct {
+	XMLName  xml.Name       `xml:"events"`
+	Started  []*OTRStarted  `xml:"started,omitempty"`
+	Finished []*OTRFinished `xml:"finished,omitempty"`
+}
+
+// OTRTestReport JUnit Platform Open Test Reporting test report structure
+type OTRTestReport struct {
+}
+
+// Parse JUnit Platform Open Test Reporting XML test result reports (e.g. open-test-report.xml)
+func (otrtr *OTRTestReport) Parse(reportRootPath string) []TestSuite {
+	defer utils.TimeTrack(time.Now(), "Scan Open Test Reporting XML test reports")
+
+	return parseReportFiles(reportRootPath, func(path string) bool {
+		return hasExtension(path, ".xml")
+	}, readContent(parseOTRFile))
+}
+
+func parseOTRFile(xmlFilePath string, xfb []byte, ts []TestSuite) []TestSuite {
+	var events OTREvents
+	err := xml.Unmarshal(xfb, &events)
+	if err != nil {
+		// Most likely some other XML file (e.g. the legacy JUnit XML reports)
+		glog.Info("Not an Open Test Reporting file ", xmlFilePath, ": ", err)
+		return ts
+	}
+
+	suites := addOTREventsToTestResult(xmlFilePath, &events)
+	if suites == nil {
+		glog.Info("No test cases found in ", xmlFilePath)
+		return ts
+	}
+
+	return append(ts, suites...)
+}
+
+// Rebuild the test tree from the started/finished events and map it to the common (generalized) TestSuite struct.
+// Each container holding tests becomes a TestSuite, named by its display name hierarchy (e.g. MyTest > Nested context).
+// Tags of a test are merged with the tags of its ancestors
+func addOTREventsToTestResult(xmlFile string, events *OTREvents) []TestSuite {
+	var nodes = make(map[string]*OTRStarted)
+	var hasChildren = make(map[string]bool)
+	for _, started := range events.Started {
+		nodes[started.ID] = started
+		if started.ParentID != "" {
+			hasChildren[started.ParentID] = true
+		}
+	}
+	var finishedEvents = make(map[string]*OTRFinished)
+	for _, finished := range events.Finished {
+		finishedEvents[finished.ID] = finished
+	}
+
+	var suites []TestSuite
+	var suiteIndex = make(map[string]int)
+	for _, started := range events.Started {
+		if !started.isTest(hasChildren[started.ID]) {
+			continue
+		}
+
+		className, methodName := getOTRClassAndMethodName(started, nodes)
+		testcase := &TestCase{ReportFileName: xmlFile, ClassName: className, MethodName: methodName, Tags: getOTRTags(started, nodes), Timestamp: parseTimestamp(started.Time)}
+		finished := finishedEvents[started.ID]
+		if finished == nil {
+			testcase.Result = getOTRResult(nil)
+		} else {
+			testcase.Result = getOTRResult(finished.Result)
+			testcase.Duration = getOTRDuration(started.Time, finished.Time)
+			if finished.Result != nil {
+				testcase.StackTrace = strings.TrimSpace(finished.Result.Throwable)
+				testcase.Message = strings.TrimSpace(finished.Result.Reason)
+				if testcase.Message == "" {
+					testcase.Message = firstLine(testcase.StackTrace)
+				}
+			}
+		}
+
+		i, found := suiteIndex[started.ParentID]
+		if !found {
+			i = len(suites)
+			suiteIndex[started.ParentID] = i
+			suites = append(suites, TestSuite{Name: getOTRDisplayNamePath(nodes[started.ParentID], nodes)})
+		}
+		suites[i].TestCase = append(suites[i].TestCase, testcase)
+	}
+
+	return suites
+}
+
+// The JUnit Platform states whether a node is a test. Other producers might not, then every leaf is a test
+func (s *OTRStarted) isTest(hasChildren bool) bool {
+	if s.Metadata != nil && s.Metadata.Type != "" {
+		return s.Metadata.Type == "TEST" || s.Metadata.Type == "CONTAINER_AND_TEST"
+	}
+	return !hasChildren
+}
+
+// Class and method name are taken from the JavaMethodSource of the test (or of its closest ancestor, e.g. for
+// dynamic tests or parameterized test invocations). Without one, the closest JavaClassSource and the display name are used
+func getOTRClassAndMethodName(test *OTRStarted, nodes map[string]*OTRStarted) (string, string) {
+	var className string
+	for node := test; node != nil; node = nodes[node.ParentID] {
+		if node.Sources == nil {
+			continue
+		}
+		if node.Sources.MethodSource != nil {
+			return getJavaClassName(node.Sources.MethodSource.ClassName), node.Sources.MethodSource.MethodName
+		}
+		if node.Sources.ClassSource != nil && className == "" {
+			className = getJavaClassName(node.Sources.ClassSource.ClassName)
+		}
+	}
+	return className, test.Name
+}
+
+// Name the class the way the JavaParser does, so that test results match the traces in the sourcecode. The JavaParser
+// doesn't support inner classes of inner classes and attaches the innermost class to the outer class only
+// (e.g. com.sap.MyTest$Inner$Deeper becomes com.sap.MyTest$Deeper)
+func getJavaClassName(className string) string {
+	first := strings.Index(className, "$")
+	last := strings.LastIndex(className, "$")
+	if first == last {
+		return className
+	}
+	return className[:first] + className[last:]
+}
+
+func getOTRTags(test *OTRStarted, nodes map[string]*OTRStarted) []string {
+	var tags []string
+	var seen = make(map[string]bool)
+	for node := test; node != nil; node = nodes[node.ParentID] {
+		for _, tag := range node.Tags {
+			tag = strings.TrimSpace(tag)
+			if tag != "" && !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
+
+// Display name hierarchy of a container (without the root, which is the test engine)
+func getOTRDisplayNamePath(container *OTRStarted, nodes map[string]*OTRStarted) string {
+	var names []string
+	for node := container; node != nil && node.ParentID != ""; node = nodes[node.ParentID] {
+		names = append([]string{node.Name}, names...)
+	}
+	if len(names) == 0 && container != nil {
+		return container.Name
+	}
+	return strings.Join(names, " > ")
+}
+
+// Map Open Test Reporting result status (SUCCESSFUL, SKIPPED, ABORTED, FAILED, ERRORED) to our test result.
+// A test without result (e.g. because the test JVM crashed) failed
+func getOTRResult(result *OTRResult) int {
+	if result == nil {
+		return FAILURE
+	}
+	switch result.Status {
+	case "SUCCESSFUL":
+		return SUCCESS
+	case "SKIPPED", "ABORTED":
+		return SKIPPED
+	case "ERRORED":
+		return ERROR
+	default:
+		return FAILURE
+	}
+}
+
+func getOTRDuration(startTime, endTime string) time.Duration {
+	start, errStart := time.Parse(time.RFC3339Nano, startTime)
+	end, errEnd := time.Parse(time.RFC3339Nano, endTime)
+	if errStart != nil || errEnd != nil {
+		return 0
+	}
+	return end.Sub(start)
+}
@@ -0,0 +1,138 @@
+package testreport
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseOTRFile(t *testing.T) {
+	fp := "open-test-report.xml"
+
+	x := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+		<e:events xmlns="https://schemas.opentest4j.org/reporting/core/0.1.0" xmlns:e="https://schemas.opentest4j.org/reporting/events/0.1.0" xmlns:java="https://schemas.opentest4j.org/reporting/java/0.1.0" xmlns:junit="https://schemas.junit.org/open-test-reporting">
+			<infrastructure><hostName>build</hostName></infrastructure>
+			<e:started id="1" name="JUnit Jupiter" time="2023-01-01T10:00:00Z">
+				<metadata><junit:uniqueId>[engine:junit-jupiter]</junit:uniqueId><junit:type>CONTAINER</junit:type></metadata>
+			</e:started>
+			<e:started id="2" name="My test" parentId="1" time="2023-01-01T10:00:00Z">
+				<metadata><junit:type>CONTAINER</junit:type></metadata>
+				<sources><java:classSource className="com.sap.ctm.testing.MyTest"/></sources>
+				<tags><tag>Jira:MYJIRAPROJECT-1</tag></tags>
+			</e:started>
+			<e:started id="3" name="does something" parentId="2" time="2023-01-01T10:00:00Z">
+				<metadata><junit:type>TEST</junit:type></metadata>
+				<sources><java:methodSource className="com.sap.ctm.testing.MyTest" methodName="someTest" methodParameterTypes=""/></sources>
+				<tags><tag>fast</tag><tag>Jira:MYJIRAPROJECT-1</tag></tags>
+			</e:started>
+			<e:finished id="3" time="2023-01-01T10:00:01Z"><result status="SUCCESSFUL"/></e:finished>
+			<e:started id="4" name="Inner context" parentId="2" time="2023-01-01T10:00:01Z">
+				<metadata><junit:type>CONTAINER</junit:type></metadata>
+				<sources><java:classSource className="com.sap.ctm.testing.MyTest$Inner"/></sources>
+			</e:started>
+			<e:started id="5" name="fails" parentId="4" time="2023-01-01T10:00:01Z">
+				<metadata><junit:type>TEST</junit:type></metadata>
+				<sources><java:methodSource className="com.sap.ctm.testing.MyTest$Inner" methodName="failingTest" methodParameterTypes=""/></sources>
+			</e:started>
+			<e:finished id="5" time="2023-01-01T10:00:02Z"><result status="FAILED"><java:throwable type="org.opentest4j.AssertionFailedError"><![CDATA[expected: <1> but was: <2>]]></java:throwable></result></e:finished>
+			<e:started id="6" name="Deeper context" parentId="4" time="2023-01-01T10:00:02Z">
+				<metadata><junit:type>CONTAINER</junit:type></metadata>
+				<sources><java:classSource className="com.sap.ctm.testing.MyTest$Inner$Deeper"/></sources>
+			</e:started>
+			<e:started id="7" name="is skipped" parentId="6" time="2023-01-01T10:00:02Z">
+				<metadata><junit:type>TEST</junit:type></metadata>
+				<sources><java:methodSource className="com.sap.ctm.testing.MyTest$Inner$Deeper" methodName="skippedTest" methodParameterTypes=""/></sources>
+			</e:started>
+			<e:finished id="7" time="2023-01-01T10:00:02Z"><result status="SKIPPED"><reason>disabled</reason></result></e:finished>
+			<e:finished id="6" time="2023-01-01T10:00:02Z"><result status="SUCCESSFUL"/></e:finished>
+			<e:finished id="4" time="2023-01-01T10:00:02Z"><result status="SUCCESSFUL"/></e:finished>
+			<e:started id="8" name="crashes" parentId="2" time="2023-01-01T10:00:03Z">
+				<metadata><junit:type>TEST</junit:type></metadata>
+				<sources><java:methodSource className="com.sap.ctm.testing.MyTest" methodName="crashingTest" methodParameterTypes=""/></sources>
+			</e:started>
+		</e:events>
+	`)
+
+	var ts = []TestSuite{}
+	ts = parseOTRFile(fp, x, ts)
+
+	if len(ts) != 3 {
+		t.Fatal("Should parse exactly three test suites")
+	}
+	if ts[0].Name != "My test" || ts[1].Name != "My test > Inner context" || ts[2].Name != "My test > Inner context > Deeper context" {
+		t.Error("Invalid test suite names were parsed: ", ts[0].Name, ", ", ts[1].Name, ", ", ts[2].Name)
+	}
+	checkTestCases(t, ts[0].TestCase, []expectedTestCase{
+		{"com.sap.ctm.testing.MyTest", "someTest", SUCCESS},
+		{"com.sap.ctm.testing.MyTest", "crashingTest", FAILURE},
+	})
+	checkTestCases(t, ts[1].TestCase, []expectedTestCase{
+		{"com.sap.ctm.testing.MyTest$Inner", "failingTest", FAILURE},
+	})
+	checkTestCases(t, ts[2].TestCase, []expectedTestCase{
+		{"com.sap.ctm.testing.MyTest$Deeper", "skippedTest", SKIPPED},
+	})
+
+	failing := ts[1].TestCase[0]
+	if failing.Message != "expected: <1> but was: <2>" || failing.Duration != time.Second {
+		t.Error("Invalid failure details were parsed: ", failing.Message, ", ", failing.Duration)
+	}
+	if skipped := ts[2].TestCase[0]; skipped.Message != "disabled" {
+		t.Error("Invalid skip reason was parsed: ", skipped.Message)
+	}
+
+	tags := ts[0].TestCase[0].Tags
+	if len(tags) != 2 || tags[0] != "fast" || tags[1] != "Jira:MYJIRAPROJECT-1" {
+		t.Error("Invalid tags were parsed: ", tags)
+	}
+}
+
+func TestParseOTRFileParameterizedTest(t *testing.T) {
+	fp := "open-test-report.xml"
+
+	x := []byte(`
+		<e:events xmlns="https://schemas.opentest4j.org/reporting/core/0.1.0" xmlns:e="https://schemas.opentest4j.org/reporting/events/0.1.0" xmlns:java="https://schemas.opentest4j.org/reporting/java/0.1.0" xmlns:junit="https://schemas.junit.org/open-test-reporting">
+			<e:started id="1" name="JUnit Jupiter"><metadata><junit:type>CONTAINER</junit:type></metadata></e:started>
+			<e:started id="2" name="MyTest" parentId="1">
+				<metadata><junit:type>CONTAINER</junit:type></metadata>
+				<sources><java:classSource className="com.sap.ctm.testing.MyTest"/></sources>
+			</e:started>
+			<e:started id="3" name="paramTest(int)" parentId="2">
+				<metadata><junit:type>CONTAINER</junit:type></metadata>
+				<sources><java:methodSource className="com.sap.ctm.testing.MyTest" methodName="paramTest" methodParameterTypes="int"/></sources>
+			</e:started>
+			<e:started id="4" name="[1] 1" parentId="3"><metadata><junit:type>TEST</junit:type></metadata></e:started>
+			<e:finished id="4"><result status="SUCCESSFUL"/></e:finished>
+			<e:started id="5" name="[2] 2" parentId="3"><metadata><junit:type>TEST</junit:type></metadata></e:started>
+			<e:finished id="5"><result status="ERRORED"/></e:finished>
+			<e:finished id="3"><result status="SUCCESSFUL"/></e:finished>
+		</e:events>
+	`)
+
+	var ts = []TestSuite{}
+	ts = parseOTRFile(fp, x, ts)
+
+	if len(ts) != 1 {
+		t.Fatal("Should parse exactly one test suite")
+	}
+	checkTestCases(t, ts[0].TestCase, []expectedTestCase{
+		{"com.sap.ctm.testing.MyTest", "paramTest", SUCCESS},
+		{"com.sap.ctm.testing.MyTest", "paramTest", ERROR},
+	})
+}
+
+func TestParseOTRFileIgnoresOtherXML(t *testing.T) {
+	fp := "TEST-com.sap.ctm.testing.MyTest.xml"
+
+	x := []byte(`
+		<testsuite name="com.sap.ctm.testing.MyTest" tests="1" errors="0" failures="0">
+			<testcase name="someTest" classname="com.sap.ctm.testing.MyTest"/>
+		</testsuite>
+	`)
+
+	var ts = []TestSuite{}
+	ts = parseOTRFile(fp, x, ts)
+
+	if len(ts) != 0 {
+		t.Error("Legacy JUnit XML reports should be ignored")
+	}
+}
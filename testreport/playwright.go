@@ -0,0 +1,181 @@
+package testreport
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/SAP/quality-continuous-traceability-monitor/utils"
+	"github.com/golang/glog"
+)
+
+// PlaywrightError Playwright error struct
+type PlaywrightError struct {
+	Message string `json:"message,omitempty"`
+	Stack   string `json:"stack,omitempty"`
+}
+
+// PlaywrightResult Playwright test result (one run/retry of a test) structure
+type PlaywrightResult struct {
+	Status    string           `json:"status"`
+	Duration  float64          `json:"duration"`
+	Retry     int              `json:"retry"`
+	StartTime string           `json:"startTime,omitempty"`
+	Error     *PlaywrightError `json:"error,omitempty"`
+}
+
+// PlaywrightTest Playwright test (a spec run within one project) structure
+type PlaywrightTest struct {
+	ProjectID      string              `json:"projectId,omitempty"`
+	ProjectName    string              `json:"projectName"`
+	ExpectedStatus string              `json:"expectedStatus"`
+	Status         string              `json:"status"`
+	Results        []*PlaywrightResult `json:"results"`
+}
+
+// PlaywrightSpec Playwright spec structure
+type PlaywrightSpec struct {
+	Title string            `json:"title"`
+	OK    bool              `json:"ok"`
+	Tags  []string          `json:"tags,omitempty"`
+	File  string            `json:"file,omitempty"`
+	Line  int               `json:"line,omitempty"`
+	Tests []*PlaywrightTest `json:"tests"`
+}
+
+// PlaywrightSuite Playwright suite (file or describe block) structure
+type PlaywrightSuite struct {
+	Title  string             `json:"title"`
+	File   string             `json:"file,omitempty"`
+	Specs  []*PlaywrightSpec  `json:"specs,omitempty"`
+	Suites []*PlaywrightSuite `json:"suites,omitempty"`
+}
+
+// PlaywrightReport Playwright JSON reporter structure
+type PlaywrightReport struct {
+	Config *json.RawMessage   `json:"config"`
+	Suites []*PlaywrightSuite `json:"suites"`
+}
+
+// PlaywrightTestReport Playwright JSON test report structure
+type PlaywrightTestReport struct {
+}
+
+// Parse Playwright JSON test result reports (playwright test --reporter=json)
+func (pwtr *PlaywrightTestReport) Parse(reportRootPath string) []TestSuite {
+	defer utils.TimeTrack(time.Now(), "Scan Playwright JSON test reports")
+
+	return parseReportFiles(reportRootPath, func(path string) bool {
+		return hasExtension(path, ".json")
+	}, readContent(parsePlaywrightFile))
+}
+
+func parsePlaywrightFile(jsonFilePath string, jfb []byte, ts []TestSuite) []TestSuite {
+	var report PlaywrightReport
+	err := json.Unmarshal(jfb, &report)
+	if err != nil || report.Config == nil || report.Suites == nil {
+		glog.Info("Not a Playwright JSON file ", jsonFilePath, ": ", err)
+		return ts
+	}
+
+	var found = len(ts)
+	for _, fileSuite := range report.Suites {
+		ts = addPlaywrightFileSuiteToTestResult(jsonFilePath, ts, fileSuite)
+	}
+	if found == len(ts) {
+		glog.Info("No test cases found in ", jsonFilePath)
+	}
+
+	return ts
+}
+
+// Map the Playwright suite of one test file to the common (generalized) TestSuite struct. Each spec is run once per
+// project (e.g. chromium, firefox, webkit), so we create one TestCase per spec per project with the project as variant
+// and one TestSuite per file and project. Just like the JSParser does, the titles of the (nested) describe blocks
+// are joined with spaces into the class name and the test title is used as method name
+func addPlaywrightFileSuiteToTestResult(jsonFile string, ts []TestSuite, fileSuite *PlaywrightSuite) []TestSuite {
+	var suites []TestSuite
+	var suiteIndex = make(map[string]int)
+
+	var addSpecs func(describes []string, suite *PlaywrightSuite)
+	addSpecs = func(describes []string, suite *PlaywrightSuite) {
+		className := strings.Join(describes, " ")
+		if className == "" { // Test outside of a describe block
+			className = fileSuite.Title
+		}
+
+		for _, spec := range suite.Specs {
+			var tags []string
+			for _, tag := range spec.Tags {
+				tags = append(tags, strings.TrimPrefix(tag, "@"))
+			}
+
+			for _, test := range spec.Tests {
+				testcase := &TestCase{ReportFileName: jsonFile, ClassName: className, MethodName: spec.Title, Result: test.getResult(), Tags: tags, Variant: test.ProjectName}
+				if len(test.Results) > 0 { // The test started with its first result, the last result (retry) decides
+					testcase.Timestamp = parseTimestamp(test.Results[0].StartTime)
+					last := test.Results[len(test.Results)-1]
+					testcase.Duration = parseMilliseconds(last.Duration)
+					if last.Error != nil {
+						testcase.Message = firstLine(last.Error.Message)
+						testcase.StackTrace = strings.TrimSpace(last.Error.Stack)
+					}
+				}
+
+				suiteName := fileSuite.Title
+				if test.ProjectName != "" {
+					suiteName = suiteName + " [" + test.ProjectName + "]"
+				}
+				i, found := suiteIndex[suiteName]
+				if !found {
+					i = len(suites)
+					suiteIndex[suiteName] = i
+					suites = append(suites, TestSuite{Name: suiteName})
+				}
+				suites[i].TestCase = append(suites[i].TestCase, testcase)
+			}
+		}
+
+		for _, child := range suite.Suites {
+			addSpecs(append(append([]string{}, describes...), child.Title), child)
+		}
+	}
+	addSpecs(nil, fileSuite)
+
+	return append(ts, suites...)
+}
+
+// Map Playwright test status (expected, unexpected, flaky, skipped) to our test result. A test which passed only
+// after a retry is flaky (not successful)
+func (pwt *PlaywrightTest) getResult() int {
+	switch pwt.Status {
+	case "expected":
+		if pwt.ExpectedStatus == "skipped" {
+			return SKIPPED
+		}
+		return SUCCESS
+	case "flaky":
+		return FLAKY
+	case "skipped":
+		return SKIPPED
+	case "unexpected":
+		return FAILURE
+	}
+
+	// No overall status given. Derive it from the results of all retries
+	if len(pwt.Results) == 0 {
+		return SKIPPED
+	}
+	last := pwt.Results[len(pwt.Results)-1]
+	switch last.Status {
+	case "passed":
+		if len(pwt.Results) > 1 {
+			return FLAKY
+		}
+		return SUCCESS
+	case "skipped":
+		return SKIPPED
+	default: // failed, timedOut, interrupted
+		return FAILURE
+	}
+}
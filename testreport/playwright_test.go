@@ -0,0 +1,96 @@
+package testreport
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePlaywrightFile(t *testing.T) {
+	fp := "playwright-results.json"
+
+	x := []byte(`{
+		"config": {"version": "1.40.0"},
+		"suites": [
+			{
+				"title": "login.spec.ts",
+				"file": "login.spec.ts",
+				"specs": [
+					{"title": "has title", "ok": true, "tags": [], "file": "login.spec.ts", "line": 3, "tests": [
+						{"projectName": "chromium", "expectedStatus": "passed", "status": "expected", "results": [{"status": "passed", "retry": 0}]}
+					]}
+				],
+				"suites": [
+					{
+						"title": "Login",
+						"file": "login.spec.ts",
+						"suites": [
+							{
+								"title": "with valid user",
+								"file": "login.spec.ts",
+								"specs": [
+									{"title": "logs in", "ok": false, "tags": ["@Jira:MYJIRAPROJECT-1"], "file": "login.spec.ts", "line": 10, "tests": [
+										{"projectName": "chromium", "expectedStatus": "passed", "status": "expected", "results": [{"status": "passed", "retry": 0}]},
+										{"projectName": "firefox", "expectedStatus": "passed", "status": "flaky", "results": [{"status": "failed", "retry": 0, "startTime": "2019-11-05T10:26:10.100Z"}, {"status": "passed", "retry": 1, "startTime": "2019-11-05T10:26:11.100Z"}]},
+										{"projectName": "webkit", "expectedStatus": "passed", "status": "unexpected", "results": [{"status": "failed", "retry": 0}, {"status": "timedOut", "retry": 1}]}
+									]}
+								]
+							}
+						],
+						"specs": [
+							{"title": "remembers the user", "ok": true, "tags": [], "file": "login.spec.ts", "line": 20, "tests": [
+								{"projectName": "chromium", "expectedStatus": "skipped", "status": "skipped", "results": [{"status": "skipped", "retry": 0}]},
+								{"projectName": "firefox", "expectedStatus": "passed", "results": [{"status": "failed", "retry": 0}, {"status": "passed", "retry": 1}]}
+							]}
+						]
+					}
+				]
+			}
+		]
+	}`)
+
+	var ts = []TestSuite{}
+	ts = parsePlaywrightFile(fp, x, ts)
+
+	if len(ts) != 3 {
+		t.Fatal("Should parse exactly three test suites (one per file and project)")
+	}
+	if ts[0].Name != "login.spec.ts [chromium]" || ts[1].Name != "login.spec.ts [firefox]" || ts[2].Name != "login.spec.ts [webkit]" {
+		t.Error("Invalid test suite names were parsed")
+	}
+	checkTestCases(t, ts[0].TestCase, []expectedTestCase{
+		{"login.spec.ts", "has title", SUCCESS},
+		{"Login", "remembers the user", SKIPPED},
+		{"Login with valid user", "logs in", SUCCESS},
+	})
+	checkTestCases(t, ts[1].TestCase, []expectedTestCase{
+		{"Login", "remembers the user", FLAKY},
+		{"Login with valid user", "logs in", FLAKY},
+	})
+	checkTestCases(t, ts[2].TestCase, []expectedTestCase{
+		{"Login with valid user", "logs in", FAILURE},
+	})
+
+	webkit := ts[2].TestCase[0]
+	if webkit.Variant != "webkit" {
+		t.Error("Project should be kept as variant, got: ", webkit.Variant)
+	}
+	if len(webkit.Tags) != 1 || webkit.Tags[0] != "Jira:MYJIRAPROJECT-1" {
+		t.Error("Invalid tags were parsed: ", webkit.Tags)
+	}
+	if timestamp := ts[1].TestCase[1].Timestamp; !timestamp.Equal(time.Date(2019, 11, 5, 10, 26, 10, 100000000, time.UTC)) {
+		t.Error("Start time of the first result should be parsed, got: ", timestamp)
+	}
+}
+
+func TestParsePlaywrightFileIgnoresOtherJSON(t *testing.T) {
+	fp := "package.json"
+
+	x := []byte(`{"name": "my-app", "version": "1.0.0"}`)
+
+	var ts = []TestSuite{}
+	ts = parsePlaywrightFile(fp, x, ts)
+
+	if len(ts) != 0 {
+		t.Error("Should not parse a test suite from a non Playwright JSON file")
+	}
+}
@@ -0,0 +1,241 @@
+package testreport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/SAP/quality-continuous-traceability-monitor/utils"
+	"github.com/golang/glog"
+)
+
+// PytestPhase pytest test phase (setup, call or teardown) result struct
+type PytestPhase struct {
+	Duration float64         `json:"duration"`
+	Outcome  string          `json:"outcome"`
+	Longrepr json.RawMessage `json:"longrepr,omitempty"`
+}
+
+// PytestTest pytest test structure (as written by pytest-json-report)
+type PytestTest struct {
+	NodeID   string       `json:"nodeid"`
+	Lineno   int          `json:"lineno,omitempty"`
+	Outcome  string       `json:"outcome"`
+	Setup    *PytestPhase `json:"setup,omitempty"`
+	Call     *PytestPhase `json:"call,omitempty"`
+	Teardown *PytestPhase `json:"teardown,omitempty"`
+}
+
+// PytestJSONReport pytest-json-report (pytest --json-report) structure
+type PytestJSONReport struct {
+	Created  float64       `json:"created"`
+	Root     string        `json:"root,omitempty"`
+	ExitCode int           `json:"exitcode"`
+	Tests    []*PytestTest `json:"tests"`
+}
+
+// PytestLogEntry pytest-reportlog (pytest --report-log) entry. Each phase of a test is written as separate TestReport entry
+type PytestLogEntry struct {
+	ReportType string          `json:"$report_type"`
+	NodeID     string          `json:"nodeid,omitempty"`
+	When       string          `json:"when,omitempty"`
+	Outcome    string          `json:"outcome,omitempty"`
+	Duration   float64         `json:"duration,omitempty"`
+	Longrepr   json.RawMessage `json:"longrepr,omitempty"`
+	WasXFail   *string         `json:"wasxfail,omitempty"`
+}
+
+// PytestTestReport pytest JSON (pytest-json-report and pytest-reportlog) test report structure
+type PytestTestReport struct {
+}
+
+// Parse pytest JSON test result reports (pytest --json-report or pytest --report-log)
+func (ptr *PytestTestReport) Parse(reportRootPath string) []TestSuite {
+	defer utils.TimeTrack(time.Now(), "Scan pytest JSON test reports")
+
+	return parseReportFiles(reportRootPath, func(path string) bool {
+		return hasExtension(path, ".json", ".jsonl")
+	}, readContent(parsePytestFile))
+}
+
+func parsePytestFile(jsonFilePath string, jfb []byte, ts []TestSuite) []TestSuite {
+	var tests []*PytestTest
+
+	// pytest-json-report writes one JSON document, pytest-reportlog one JSON document per line
+	var jsonReport PytestJSONReport
+	if json.Unmarshal(jfb, &jsonReport) == nil && jsonReport.Tests != nil {
+		tests = jsonReport.Tests
+	} else {
+		tests = parsePytestReportLog(jsonFilePath, jfb)
+	}
+
+	if tests == nil {
+		glog.Info("No pytest test cases found in ", jsonFilePath)
+		return ts
+	}
+
+	return addPytestTestsToTestResult(jsonFilePath, ts, tests)
+}
+
+// Collect the phases (setup, call, teardown) of all tests from a pytest-reportlog file
+func parsePytestReportLog(jsonFilePath string, jfb []byte) []*PytestTest {
+	var tests []*PytestTest
+	var testIndex = make(map[string]*PytestTest)
+
+	reader := bufio.NewReader(bytes.NewReader(jfb))
+	for {
+		line, err := reader.ReadBytes('\n')
+		line = bytes.TrimSpace(line)
+
+		var entry PytestLogEntry
+		if len(line) > 0 && json.Unmarshal(line, &entry) == nil && entry.ReportType == "TestReport" && entry.NodeID != "" {
+			test, found := testIndex[entry.NodeID]
+			if !found {
+				test = &PytestTest{NodeID: entry.NodeID}
+				testIndex[entry.NodeID] = test
+				tests = append(tests, test)
+			}
+
+			phase := &PytestPhase{Duration: entry.Duration, Outcome: entry.Outcome, Longrepr: entry.Longrepr}
+			if entry.WasXFail != nil { // Expected failures are reported as skipped, unexpected passes as passed
+				if entry.Outcome == "skipped" {
+					phase.Outcome = "xfailed"
+				} else if entry.Outcome == "passed" {
+					phase.Outcome = "xpassed"
+				}
+			}
+			switch entry.When {
+			case "setup":
+				test.Setup = phase
+			case "call":
+				test.Call = phase
+			case "teardown":
+				test.Teardown = phase
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			glog.Error("Unable to read pytest report log ", jsonFilePath, ": ", err)
+			break
+		}
+	}
+
+	return tests
+}
+
+// Map the pytest tests to the common (generalized) TestSuite struct. The node ID is converted to the
+// package qualified class and method names the PythonParser creates. One TestSuite per class is created
+func addPytestTestsToTestResult(jsonFile string, ts []TestSuite, tests []*PytestTest) []TestSuite {
+	var suites []TestSuite
+	var suiteIndex = make(map[string]int)
+	for _, test := range tests {
+		className, methodName := getPytestClassAndMethodName(test.NodeID)
+		testcase := &TestCase{ReportFileName: jsonFile, ClassName: className, MethodName: methodName, Result: test.getResult()}
+		for _, phase := range []*PytestPhase{test.Setup, test.Call, test.Teardown} {
+			if phase == nil {
+				continue
+			}
+			testcase.Duration += time.Duration(phase.Duration * float64(time.Second))
+			if longrepr := phase.getLongrepr(); longrepr != "" && testcase.StackTrace == "" {
+				testcase.StackTrace = longrepr
+				testcase.Message = firstLine(longrepr)
+			}
+		}
+
+		i, found := suiteIndex[className]
+		if !found {
+			i = len(suites)
+			suiteIndex[className] = i
+			suites = append(suites, TestSuite{Name: className})
+		}
+		suites[i].TestCase = append(suites[i].TestCase, testcase)
+	}
+
+	return append(ts, suites...)
+}
+
+// Converts a pytest node ID (e.g. tests/test_x.py::TestCls::test_y[param]) into class (tests.test_x.TestCls) and
+// method name (test_y). Test parameters are cut off, as the sourcecode only knows the plain test method
+func getPytestClassAndMethodName(nodeID string) (string, string) {
+	parts := strings.Split(nodeID, "::")
+
+	module := strings.Replace(parts[0], "\\", "/", -1)
+	module = strings.TrimSuffix(module, ".py")
+	module = strings.Replace(module, "/", ".", -1)
+	if len(parts) == 1 {
+		return module, ""
+	}
+
+	methodName := parts[len(parts)-1]
+	if i := strings.Index(methodName, "["); i != -1 {
+		methodName = methodName[:i]
+	}
+
+	className := strings.Join(append([]string{module}, parts[1:len(parts)-1]...), ".")
+	return className, methodName
+}
+
+// Derive the test result from its phases. A failure in setup or teardown is reported as ERROR, while a failure
+// in the test itself (call) is reported as FAILURE
+func (pt *PytestTest) getResult() int {
+	if pt.Setup == nil && pt.Call == nil && pt.Teardown == nil { // No phases given. Fall back to overall outcome
+		return getPytestResult(pt.Outcome)
+	}
+
+	if pt.Setup != nil && pt.Setup.Outcome == "failed" {
+		return ERROR
+	}
+
+	var result = SKIPPED // Test wasn't called (e.g. skipped during setup)
+	if pt.Call != nil {
+		result = getPytestResult(pt.Call.Outcome)
+	}
+
+	if pt.Teardown != nil && pt.Teardown.Outcome == "failed" && result != FAILURE {
+		return ERROR
+	}
+
+	return result
+}
+
+// Map pytest outcome (passed, failed, skipped, error, xfailed, xpassed) to our test result.
+// Expected failures (xfailed) count as skipped, unexpected passes (xpassed) as success
+func getPytestResult(outcome string) int {
+	switch outcome {
+	case "passed", "xpassed":
+		return SUCCESS
+	case "skipped", "xfailed":
+		return SKIPPED
+	case "error":
+		return ERROR
+	default:
+		return FAILURE
+	}
+}
+
+// The longrepr (failure representation) of a phase is either a string or (pytest-reportlog) a serialized
+// report. For the latter the crash message is used
+func (pp *PytestPhase) getLongrepr() string {
+	if pp.Longrepr == nil {
+		return ""
+	}
+	var text string
+	if json.Unmarshal(pp.Longrepr, &text) == nil {
+		return strings.TrimSpace(text)
+	}
+	var serialized struct {
+		ReprCrash *struct {
+			Message string `json:"message"`
+		} `json:"reprcrash"`
+	}
+	if json.Unmarshal(pp.Longrepr, &serialized) == nil && serialized.ReprCrash != nil {
+		return strings.TrimSpace(serialized.ReprCrash.Message)
+	}
+	return ""
+}
@@ -0,0 +1,86 @@
+package testreport
+
+import (
+	"testing"
+)
+
+func TestParsePytestJSONReport(t *testing.T) {
+	fp := ".report.json"
+
+	x := []byte(`{
+		"created": 1572949570.1,
+		"duration": 0.5,
+		"exitcode": 1,
+		"root": "/tmp/myapp",
+		"summary": {"passed": 2, "failed": 1, "error": 2, "total": 5},
+		"tests": [
+			{"nodeid": "tests/test_login.py::TestLogin::test_valid_login", "lineno": 10, "outcome": "passed",
+			 "setup": {"duration": 0.1, "outcome": "passed"}, "call": {"duration": 0.1, "outcome": "passed"}, "teardown": {"duration": 0.1, "outcome": "passed"}},
+			{"nodeid": "tests/test_login.py::TestLogin::test_invalid_login[admin]", "lineno": 20, "outcome": "failed",
+			 "setup": {"duration": 0.1, "outcome": "passed"}, "call": {"duration": 0.1, "outcome": "failed", "longrepr": "assert False"}, "teardown": {"duration": 0.1, "outcome": "passed"}},
+			{"nodeid": "tests/test_login.py::TestLogin::test_invalid_login[guest]", "lineno": 20, "outcome": "error",
+			 "setup": {"duration": 0.1, "outcome": "failed", "longrepr": "fixture 'db' not found"}, "teardown": {"duration": 0.1, "outcome": "passed"}},
+			{"nodeid": "tests/test_login.py::TestLogin::test_logout", "lineno": 30, "outcome": "error",
+			 "setup": {"duration": 0.1, "outcome": "passed"}, "call": {"duration": 0.1, "outcome": "passed"}, "teardown": {"duration": 0.1, "outcome": "failed"}},
+			{"nodeid": "tests/test_utils.py::test_helper", "lineno": 5, "outcome": "skipped",
+			 "setup": {"duration": 0.1, "outcome": "skipped"}, "teardown": {"duration": 0.1, "outcome": "passed"}}
+		]
+	}`)
+
+	var ts = []TestSuite{}
+	ts = parsePytestFile(fp, x, ts)
+
+	if len(ts) != 2 {
+		t.Fatal("Should parse exactly two test suites (one per class)")
+	}
+	checkTestCases(t, ts[0].TestCase, []expectedTestCase{
+		{"tests.test_login.TestLogin", "test_valid_login", SUCCESS},
+		{"tests.test_login.TestLogin", "test_invalid_login", FAILURE},
+		{"tests.test_login.TestLogin", "test_invalid_login", ERROR},
+		{"tests.test_login.TestLogin", "test_logout", ERROR},
+	})
+	checkTestCases(t, ts[1].TestCase, []expectedTestCase{
+		{"tests.test_utils", "test_helper", SKIPPED},
+	})
+}
+
+func TestParsePytestReportLog(t *testing.T) {
+	fp := "report-log.jsonl"
+
+	x := []byte(`{"pytest_version": "7.4.0", "$report_type": "SessionStart"}
+{"nodeid": "tests/test_login.py::TestLogin::test_valid_login", "location": ["tests/test_login.py", 9, "TestLogin.test_valid_login"], "outcome": "passed", "longrepr": null, "when": "setup", "duration": 0.1, "$report_type": "TestReport"}
+{"nodeid": "tests/test_login.py::TestLogin::test_valid_login", "location": ["tests/test_login.py", 9, "TestLogin.test_valid_login"], "outcome": "passed", "longrepr": null, "when": "call", "duration": 0.1, "$report_type": "TestReport"}
+{"nodeid": "tests/test_login.py::TestLogin::test_valid_login", "location": ["tests/test_login.py", 9, "TestLogin.test_valid_login"], "outcome": "failed", "longrepr": "RuntimeError", "when": "teardown", "duration": 0.1, "$report_type": "TestReport"}
+{"nodeid": "tests/test_login.py::TestLogin::test_invalid_login", "location": ["tests/test_login.py", 19, "TestLogin.test_invalid_login"], "outcome": "passed", "longrepr": null, "when": "setup", "duration": 0.1, "$report_type": "TestReport"}
+{"nodeid": "tests/test_login.py::TestLogin::test_invalid_login", "location": ["tests/test_login.py", 19, "TestLogin.test_invalid_login"], "outcome": "failed", "longrepr": "assert False", "when": "call", "duration": 0.1, "$report_type": "TestReport"}
+{"nodeid": "tests/test_login.py::TestLogin::test_invalid_login", "location": ["tests/test_login.py", 19, "TestLogin.test_invalid_login"], "outcome": "passed", "longrepr": null, "when": "teardown", "duration": 0.1, "$report_type": "TestReport"}
+{"nodeid": "tests/test_login.py::TestLogin::test_known_bug", "location": ["tests/test_login.py", 29, "TestLogin.test_known_bug"], "outcome": "passed", "longrepr": null, "when": "setup", "duration": 0.1, "$report_type": "TestReport"}
+{"nodeid": "tests/test_login.py::TestLogin::test_known_bug", "location": ["tests/test_login.py", 29, "TestLogin.test_known_bug"], "outcome": "skipped", "wasxfail": "", "longrepr": null, "when": "call", "duration": 0.1, "$report_type": "TestReport"}
+{"exitstatus": 1, "$report_type": "SessionFinish"}
+`)
+
+	var ts = []TestSuite{}
+	ts = parsePytestFile(fp, x, ts)
+
+	if len(ts) != 1 {
+		t.Fatal("Should parse exactly one test suite")
+	}
+	checkTestCases(t, ts[0].TestCase, []expectedTestCase{
+		{"tests.test_login.TestLogin", "test_valid_login", ERROR},
+		{"tests.test_login.TestLogin", "test_invalid_login", FAILURE},
+		{"tests.test_login.TestLogin", "test_known_bug", SKIPPED},
+	})
+}
+
+func TestParsePytestFileIgnoresOtherJSON(t *testing.T) {
+	fp := "package.json"
+
+	x := []byte(`{"name": "my-app", "version": "1.0.0"}`)
+
+	var ts = []TestSuite{}
+	ts = parsePytestFile(fp, x, ts)
+
+	if len(ts) != 0 {
+		t.Error("Should not parse a test suite from a non pytest JSON file")
+	}
+}
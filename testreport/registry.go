@@ -0,0 +1,73 @@
+package testreport
+
+// registeredTestReport maps a test report type (as used in the testReport configuration) to its parser
+type registeredTestReport struct {
+	reportType string
+	testReport TestReport
+}
+
+// Supported test report types
+// xunit-xml format = https://github.com/windyroad/JUnit-Schema/blob/master/JUnit.xsd
+// testng-xml format = TestNG results (testng-results.xml)
+// nunit-xml format = https://docs.nunit.org/articles/nunit/technical-notes/usage/Test-Result-XML-Format.html (NUnit 3 TestResult.xml)
+// trx format = Visual Studio test results (e.g. written by dotnet test --logger trx)
+// cucumber-json format = Cucumber JSON formatter output
+// go-test-json format = go test -json output (see go doc test2json)
+// tap format = https://testanything.org (TAP version 12, 13 and 14)
+// allure format = Allure results directory (<uuid>-result.json and <uuid>-container.json files)
+// robot-xml format = Robot Framework output.xml
+// jest-json format = jest --json output
+// mocha-json format = mocha --reporter json output
+// ctrf-json format = https://ctrf.io (Common Test Report Format)
+// pytest-json format = pytest --json-report (pytest-json-report) or pytest --report-log (pytest-reportlog) output
+// playwright-json format = playwright test --reporter=json output
+// open-test-reporting format = JUnit Platform Open Test Reporting event XML (JUnit 5.9+)
+// auto = Detects the format of each file (any of the above) on its own
+var testReportRegistry = []registeredTestReport{
+	{"xunit-xml", &XUTestReport{}},
+	{"testng-xml", &TNGTestReport{}},
+	{"nunit-xml", &NUTestReport{}},
+	{"trx", &TRXTestReport{}},
+	{"cucumber-json", &CucumberTestReport{}},
+	{"go-test-json", &GoTestReport{}},
+	{"tap", &TAPTestReport{}},
+	{"allure", &AllureTestReport{}},
+	{"robot-xml", &RobotTestReport{}},
+	{"jest-json", &JestTestReport{}},
+	{"mocha-json", &MochaTestReport{}},
+	{"ctrf-json", &CTRFTestReport{}},
+	{"pytest-json", &PytestTestReport{}},
+	{"playwright-json", &PlaywrightTestReport{}},
+	{"open-test-reporting", &OTRTestReport{}},
+	{"auto", &AutoTestReport{}},
+}
+
+// RegisterTestReport adds a parser for a test report type (or replaces the parser of an already registered type)
+func RegisterTestReport(reportType string, testReport TestReport) {
+	for i, registered := range testReportRegistry {
+		if registered.reportType == reportType {
+			testReportRegistry[i].testReport = testReport
+			return
+		}
+	}
+	testReportRegistry = append(testReportRegistry, registeredTestReport{reportType, testReport})
+}
+
+// GetTestReport returns the parser for a test report type (and false, if the type isn't supported)
+func GetTestReport(reportType string) (TestReport, bool) {
+	for _, registered := range testReportRegistry {
+		if registered.reportType == reportType {
+			return registered.testReport, true
+		}
+	}
+	return nil, false
+}
+
+// SupportedTestReportTypes returns all registered test report types
+func SupportedTestReportTypes() []string {
+	var reportTypes []string
+	for _, registered := range testReportRegistry {
+		reportTypes = append(reportTypes, registered.reportType)
+	}
+	return reportTypes
+}
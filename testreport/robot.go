@@ -0,0 +1,152 @@
+package testreport
+
+import (
+	"encoding/xml"
+	"strings"
+	"time"
+
+	"github.com/SAP/quality-continuous-traceability-monitor/utils"
+	"github.com/golang/glog"
+)
+
+// RobotStatus Robot Framework status struct
+type RobotStatus struct {
+	Status    string `xml:"status,attr"`
+	StartTime string `xml:"starttime,attr,omitempty"` // Robot Framework < 7
+	EndTime   string `xml:"endtime,attr,omitempty"`   // Robot Framework < 7
+	Start     string `xml:"start,attr,omitempty"`     // Robot Framework >= 7
+	Elapsed   string `xml:"elapsed,attr,omitempty"`   // Robot Framework >= 7
+	Message   string `xml:",chardata"`
+}
+
+// RobotTest Robot Framework test structure
+type RobotTest struct {
+	ID     string       `xml:"id,attr"`
+	Name   string       `xml:"name,attr"`
+	Line   string       `xml:"line,attr,omitempty"`
+	Doc    string       `xml:"doc,omitempty"`
+	Tag    []string     `xml:"tag,omitempty"`      // Robot Framework >= 4
+	Tags   []string     `xml:"tags>tag,omitempty"` // Robot Framework < 4
+	Status *RobotStatus `xml:"status"`
+}
+
+// RobotSuite Robot Framework suite structure. Suites are nested (directory -> file)
+type RobotSuite struct {
+	ID     string        `xml:"id,attr"`
+	Name   string        `xml:"name,attr"`
+	Source string        `xml:"source,attr,omitempty"`
+	Suite  []*RobotSuite `xml:"suite,omitempty"`
+	Test   []*RobotTest  `xml:"test,omitempty"`
+	Status *RobotStatus  `xml:"status"`
+}
+
+// RobotOutput Robot Framework output (root element of output.xml) structure
+type RobotOutput struct {
+	XMLName   xml.Name      `xml:"robot"`
+	Generator string        `xml:"generator,attr"`
+	Suite     []*RobotSuite `xml:"suite,omitempty"`
+}
+
+// RobotTestReport Robot Framework test report structure
+type RobotTestReport struct {
+}
+
+// Parse Robot Framework XML test result reports (output.xml)
+func (rtr *RobotTestReport) Parse(reportRootPath string) []TestSuite {
+	defer utils.TimeTrack(time.Now(), "Scan Robot Framework XML test reports")
+
+	return parseReportFiles(reportRootPath, func(path string) bool {
+		return hasExtension(path, ".xml")
+	}, readContent(parseRobotFile))
+}
+
+func parseRobotFile(xmlFilePath string, xfb []byte, ts []TestSuite) []TestSuite {
+	var robotOutput RobotOutput
+	err := xml.Unmarshal(xfb, &robotOutput)
+	if err != nil {
+		glog.Info("Not a Robot Framework output file ", xmlFilePath, ": ", err)
+		return ts
+	}
+
+	var found = len(ts)
+	for _, robotSuite := range robotOutput.Suite {
+		ts = addRobotSuiteToTestResult(xmlFilePath, "", ts, *robotSuite)
+	}
+	if found == len(ts) {
+		glog.Info("No test cases found in ", xmlFilePath)
+	}
+
+	return ts
+}
+
+// Map the Robot Framework suite (and recursively all its nested suites) to the common (generalized) TestSuite struct.
+// The suite long name (e.g. Acceptance.Login) is used as class name, the test name as method name.
+// Test tags (e.g. Jira:MYJIRAPROJECT-1) are kept, so that they can be used as requirement mapping
+func addRobotSuiteToTestResult(xmlFile string, parent string, ts []TestSuite, robotSuite RobotSuite) []TestSuite {
+	longName := robotSuite.Name
+	if parent != "" {
+		longName = parent + "." + robotSuite.Name
+	}
+
+	var testcases []*TestCase
+	for _, robotTest := range robotSuite.Test {
+		var tags []string
+		tags = append(tags, robotTest.Tags...)
+		tags = append(tags, robotTest.Tag...)
+		testcase := &TestCase{ReportFileName: xmlFile, ClassName: longName, MethodName: robotTest.Name, Result: getRobotResult(robotTest.Status), Tags: tags}
+		if robotTest.Status != nil {
+			testcase.Message = strings.TrimSpace(robotTest.Status.Message)
+			testcase.Duration = robotTest.Status.getDuration()
+			testcase.Timestamp = robotTest.Status.getTimestamp()
+		}
+		testcases = append(testcases, testcase)
+	}
+	if testcases != nil {
+		ts = append(ts, TestSuite{longName, testcases})
+	}
+
+	for _, robotChildSuite := range robotSuite.Suite {
+		ts = addRobotSuiteToTestResult(xmlFile, longName, ts, *robotChildSuite)
+	}
+
+	return ts
+}
+
+// Map Robot Framework test status (PASS, FAIL, SKIP, NOT RUN) to our test result
+func getRobotResult(status *RobotStatus) int {
+	if status == nil {
+		return FAILURE
+	}
+	switch status.Status {
+	case "PASS":
+		return SUCCESS
+	case "SKIP", "NOT RUN":
+		return SKIPPED
+	default:
+		return FAILURE
+	}
+}
+
+// Format of the start and end time written by Robot Framework < 7
+const robotTimeFormat = "20060102 15:04:05.000"
+
+// Robot Framework >= 7 writes the start time in ISO 8601 format, older versions in robotTimeFormat
+func (rs *RobotStatus) getTimestamp() time.Time {
+	if rs.Start != "" {
+		return parseTimestamp(rs.Start)
+	}
+	return parseTimestamp(rs.StartTime)
+}
+
+// Robot Framework >= 7 writes the elapsed time (in seconds), older versions start and end time
+func (rs *RobotStatus) getDuration() time.Duration {
+	if rs.Elapsed != "" {
+		return parseSeconds(rs.Elapsed)
+	}
+	start, errStart := time.Parse(robotTimeFormat, rs.StartTime)
+	end, errEnd := time.Parse(robotTimeFormat, rs.EndTime)
+	if errStart != nil || errEnd != nil {
+		return 0
+	}
+	return end.Sub(start)
+}
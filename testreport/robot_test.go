@@ -0,0 +1,90 @@
+package testreport
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRobotFile(t *testing.T) {
+	fp := "output.xml"
+
+	x := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+		<robot generator="Robot 6.1 (Python 3.11.4 on linux)" generated="20191105 10:26:10.000" rpa="false" schemaversion="4">
+			<suite id="s1" name="Acceptance" source="/tmp/acceptance">
+				<suite id="s1-s1" name="Login" source="/tmp/acceptance/login.robot">
+					<test id="s1-s1-t1" name="Valid Login" line="10">
+						<kw name="Open Browser" library="SeleniumLibrary">
+							<status status="FAIL" starttime="20191105 10:26:10.000" endtime="20191105 10:26:11.000"/>
+						</kw>
+						<tag>Jira:MYJIRAPROJECT-1</tag>
+						<tag>smoke</tag>
+						<status status="PASS" starttime="20191105 10:26:10.000" endtime="20191105 10:26:11.000"/>
+					</test>
+					<test id="s1-s1-t2" name="Invalid Login" line="20">
+						<status status="FAIL" starttime="20191105 10:26:11.000" endtime="20191105 10:26:12.000">Login page not found</status>
+					</test>
+					<test id="s1-s1-t3" name="Remember Login" line="30">
+						<status status="SKIP" starttime="20191105 10:26:12.000" endtime="20191105 10:26:12.000"/>
+					</test>
+					<status status="FAIL" starttime="20191105 10:26:10.000" endtime="20191105 10:26:12.000"/>
+				</suite>
+				<suite id="s1-s2" name="Logout" source="/tmp/acceptance/logout.robot">
+					<test id="s1-s2-t1" name="Logout" line="5">
+						<tags>
+							<tag>GitHub:myOrg/myRepo#5</tag>
+						</tags>
+						<status status="PASS" starttime="20191105 10:26:12.000" endtime="20191105 10:26:13.000"/>
+					</test>
+					<status status="PASS" starttime="20191105 10:26:12.000" endtime="20191105 10:26:13.000"/>
+				</suite>
+				<status status="FAIL" starttime="20191105 10:26:10.000" endtime="20191105 10:26:13.000"/>
+			</suite>
+		</robot>
+	`)
+
+	var ts = []TestSuite{}
+	ts = parseRobotFile(fp, x, ts)
+
+	if len(ts) != 2 {
+		t.Fatal("Should parse exactly two test suites")
+	}
+	if ts[0].Name != "Acceptance.Login" || ts[1].Name != "Acceptance.Logout" {
+		t.Error("Invalid test suite names were parsed")
+	}
+	checkTestCases(t, ts[0].TestCase, []expectedTestCase{
+		{"Acceptance.Login", "Valid Login", SUCCESS},
+		{"Acceptance.Login", "Invalid Login", FAILURE},
+		{"Acceptance.Login", "Remember Login", SKIPPED},
+	})
+	checkTestCases(t, ts[1].TestCase, []expectedTestCase{
+		{"Acceptance.Logout", "Logout", SUCCESS},
+	})
+
+	if tags := strings.Join(ts[0].TestCase[0].Tags, ","); tags != "Jira:MYJIRAPROJECT-1,smoke" {
+		t.Error("Invalid tags were parsed: ", tags)
+	}
+	if tags := strings.Join(ts[1].TestCase[0].Tags, ","); tags != "GitHub:myOrg/myRepo#5" {
+		t.Error("Invalid tags were parsed: ", tags)
+	}
+	if timestamp := ts[0].TestCase[1].Timestamp; !timestamp.Equal(time.Date(2019, 11, 5, 10, 26, 11, 0, time.UTC)) {
+		t.Error("Invalid start time was parsed: ", timestamp)
+	}
+}
+
+func TestParseRobotFileIgnoresOtherXML(t *testing.T) {
+	fp := "test_path.xml"
+
+	x := []byte(`
+		<testsuite name="Acceptance.Login" tests="1" errors="0" failures="0">
+			<testcase name="Valid Login" classname="Acceptance.Login"/>
+		</testsuite>
+	`)
+
+	var ts = []TestSuite{}
+	ts = parseRobotFile(fp, x, ts)
+
+	if len(ts) != 0 {
+		t.Error("Should not parse a test suite from a non Robot Framework XML file")
+	}
+}
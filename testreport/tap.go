@@ -0,0 +1,160 @@
+package testreport
+
+import (
+	"bufio"
+	"bytes"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SAP/quality-continuous-traceability-monitor/utils"
+	"github.com/golang/glog"
+)
+
+// TAP test point: (not) ok, optional test number, optional description (incl. optional directive)
+var reTAPTestPoint = regexp.MustCompile(`^(not ok|ok)\b\s*([0-9]*)\s*(.*)$`)
+
+// TAP directive (# SKIP or # TODO, case insensitive) at the end of a test point description
+var reTAPDirective = regexp.MustCompile(`(?i)(?:^|[^\\])#\s*(SKIP|TODO)\S*(.*)$`)
+
+// TAPTestPoint TAP test point structure
+type TAPTestPoint struct {
+	OK          bool
+	Number      int
+	Description string
+	Directive   string // SKIP or TODO (if any)
+	Reason      string // Explanation of the directive
+	Diagnostics string // YAML diagnostics block
+	Subtests    []*TAPTestPoint
+}
+
+// TAPTestReport TAP (Test Anything Protocol) test report structure
+type TAPTestReport struct {
+}
+
+// Parse TAP (version 12, 13 and 14) test result reports
+func (taptr *TAPTestReport) Parse(reportRootPath string) []TestSuite {
+	defer utils.TimeTrack(time.Now(), "Scan TAP test reports")
+
+	return parseReportFiles(reportRootPath, func(path string) bool {
+		return hasExtension(path, ".tap")
+	}, readContent(parseTAPFile))
+}
+
+// Each TAP file becomes a TestSuite. The file name (without extension) is used as class name and the test point
+// description as method name. Test points of (nested) subtests get the description of their parent test point(s)
+// attached (e.g. parent/child)
+func parseTAPFile(tapFilePath string, tfb []byte, ts []TestSuite) []TestSuite {
+	testPoints := parseTAP(tfb)
+	if testPoints == nil {
+		glog.Info("No test cases found in ", tapFilePath)
+		return ts
+	}
+
+	className := strings.TrimSuffix(filepath.Base(tapFilePath), filepath.Ext(tapFilePath))
+	testcases := addTAPTestPoints(tapFilePath, className, "", nil, testPoints)
+
+	return append(ts, TestSuite{className, testcases})
+}
+
+func addTAPTestPoints(tapFile, className, parent string, testcases []*TestCase, testPoints []*TAPTestPoint) []*TestCase {
+	for _, tp := range testPoints {
+		methodName := tp.Description
+		if methodName == "" {
+			methodName = strconv.Itoa(tp.Number)
+		}
+		if parent != "" {
+			methodName = parent + "/" + methodName
+		}
+		testcases = append(testcases, &TestCase{ReportFileName: tapFile, ClassName: className, MethodName: methodName, Result: tp.getResult(), Message: tp.Reason, StackTrace: strings.TrimSpace(tp.Diagnostics)})
+		testcases = addTAPTestPoints(tapFile, className, methodName, testcases, tp.Subtests)
+	}
+	return testcases
+}
+
+// Map the TAP test point status (ok, not ok) and directives (SKIP, TODO) to our test result.
+// TODO test points are not implemented yet. Just as skipped ones they don't count as failure
+func (tp *TAPTestPoint) getResult() int {
+	if tp.Directive != "" {
+		return SKIPPED
+	}
+	if tp.OK {
+		return SUCCESS
+	}
+	return FAILURE
+}
+
+// Parse TAP stream into (nested) test points. Subtests are indented by 4 spaces (per nesting level) and
+// are followed by their parent test point (carrying the result of the subtest)
+func parseTAP(tfb []byte) []*TAPTestPoint {
+	var levels [][]*TAPTestPoint // Test points per nesting level
+	var last *TAPTestPoint       // Last test point (to assign YAML diagnostics to)
+	var yaml []string            // YAML diagnostics block (if we're in one)
+	var inYAML bool
+
+	scanner := bufio.NewScanner(bytes.NewReader(tfb))
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := strings.Replace(scanner.Text(), "\t", "    ", -1)
+		content := strings.TrimSpace(line)
+
+		// YAML diagnostics block (starts with --- and ends with ...)
+		if inYAML {
+			if content == "..." {
+				inYAML = false
+				if last != nil {
+					last.Diagnostics = strings.Join(yaml, "\n")
+				}
+				continue
+			}
+			yaml = append(yaml, line)
+			continue
+		}
+		if content == "---" && last != nil {
+			inYAML = true
+			yaml = nil
+			continue
+		}
+
+		if strings.HasPrefix(content, "Bail out!") {
+			glog.Warning("TAP test run bailed out: ", content)
+			break
+		}
+
+		m := reTAPTestPoint.FindStringSubmatch(content)
+		if m == nil { // Plan, version, pragma, comment (incl. # Subtest) or unknown line
+			continue
+		}
+
+		level := (len(line) - len(strings.TrimLeft(line, " "))) / 4
+		for len(levels) <= level+1 {
+			levels = append(levels, nil)
+		}
+
+		tp := &TAPTestPoint{OK: m[1] == "ok"}
+		tp.Number, _ = strconv.Atoi(m[2])
+		tp.Description = m[3]
+		if d := reTAPDirective.FindStringSubmatchIndex(tp.Description); d != nil {
+			tp.Directive = strings.ToUpper(tp.Description[d[2]:d[3]])
+			tp.Reason = strings.TrimSpace(tp.Description[d[4]:d[5]])
+			tp.Description = tp.Description[:d[2]]
+			tp.Description = strings.TrimRight(strings.TrimSpace(tp.Description), "#")
+		}
+		tp.Description = strings.TrimPrefix(strings.TrimSpace(tp.Description), "- ")
+		tp.Description = strings.Replace(strings.TrimSpace(tp.Description), `\#`, "#", -1)
+
+		// All test points collected on the next level belong to this (parent) test point
+		tp.Subtests = levels[level+1]
+		levels[level+1] = nil
+
+		levels[level] = append(levels[level], tp)
+		last = tp
+	}
+
+	if len(levels) == 0 {
+		return nil
+	}
+	return levels[0]
+}
@@ -0,0 +1,88 @@
+package testreport
+
+import (
+	"testing"
+)
+
+func TestParseTAPFile(t *testing.T) {
+	fp := "reports/login.tap"
+
+	x := []byte(`TAP version 14
+1..6
+ok 1 - Input file opened
+not ok 2 - First line of the input valid
+  ---
+  message: 'First line invalid'
+  severity: fail
+  data:
+    got: 'Flirble'
+    expect: 'Fnible'
+  ...
+ok 3 - Read the rest of the file # SKIP no file given
+not ok 4 - Summarized correctly # TODO Not written yet
+# Subtest: Logout
+    1..2
+    ok 1 - clears session
+    not ok 2 - redirects to login page
+ok 5 - Logout
+ok 6 Escaped \# hash
+`)
+
+	var ts = []TestSuite{}
+	ts = parseTAPFile(fp, x, ts)
+
+	if len(ts) != 1 {
+		t.Fatal("Should parse exactly one test suite")
+	}
+	if ts[0].Name != "login" {
+		t.Error("Invalid test suite name was parsed")
+	}
+	checkTestCases(t, ts[0].TestCase, []expectedTestCase{
+		{"login", "Input file opened", SUCCESS},
+		{"login", "First line of the input valid", FAILURE},
+		{"login", "Read the rest of the file", SKIPPED},
+		{"login", "Summarized correctly", SKIPPED},
+		{"login", "Logout", SUCCESS},
+		{"login", "Logout/clears session", SUCCESS},
+		{"login", "Logout/redirects to login page", FAILURE},
+		{"login", "Escaped # hash", SUCCESS},
+	})
+}
+
+func TestParseTAPDiagnostics(t *testing.T) {
+	x := []byte(`TAP version 13
+1..1
+not ok 1 - First line of the input valid
+  ---
+  message: 'First line invalid'
+  ...
+`)
+
+	tps := parseTAP(x)
+	if len(tps) != 1 {
+		t.Fatal("Should parse exactly one test point")
+	}
+	if tps[0].Diagnostics != "  message: 'First line invalid'" {
+		t.Error("Invalid YAML diagnostics were parsed: ", tps[0].Diagnostics)
+	}
+}
+
+func TestParseTAPBailOut(t *testing.T) {
+	fp := "db.tap"
+
+	x := []byte(`1..3
+ok 1 - connect
+Bail out! Database unavailable
+ok 2 - query
+`)
+
+	var ts = []TestSuite{}
+	ts = parseTAPFile(fp, x, ts)
+
+	if len(ts) != 1 {
+		t.Fatal("Should parse exactly one test suite")
+	}
+	checkTestCases(t, ts[0].TestCase, []expectedTestCase{
+		{"db", "connect", SUCCESS},
+	})
+}
@@ -0,0 +1,132 @@
+package testreport
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SAP/quality-continuous-traceability-monitor/utils"
+	"github.com/golang/glog"
+)
+
+// TNGException TestNG exception struct
+type TNGException struct {
+	Class          string `xml:"class,attr"`
+	Message        string `xml:"message"`
+	FullStacktrace string `xml:"full-stacktrace"`
+}
+
+// TNGTestMethod TestNG test method structure
+type TNGTestMethod struct {
+	Name        string        `xml:"name,attr"`
+	Status      string        `xml:"status,attr"`
+	Signature   string        `xml:"signature,attr,omitempty"`
+	IsConfig    bool          `xml:"is-config,attr,omitempty"`
+	DurationMs  string        `xml:"duration-ms,attr,omitempty"`
+	StartedAt   string        `xml:"started-at,attr,omitempty"`
+	FinishedAt  string        `xml:"finished-at,attr,omitempty"`
+	Description string        `xml:"description,attr,omitempty"`
+	Exception   *TNGException `xml:"exception,omitempty"`
+}
+
+// TNGClass TestNG class structure
+type TNGClass struct {
+	Name       string           `xml:"name,attr"`
+	TestMethod []*TNGTestMethod `xml:"test-method,omitempty"`
+}
+
+// TNGTest TestNG test structure
+type TNGTest struct {
+	Name  string      `xml:"name,attr"`
+	Class []*TNGClass `xml:"class,omitempty"`
+}
+
+// TNGSuite TestNG suite structure
+type TNGSuite struct {
+	Name string     `xml:"name,attr"`
+	Test []*TNGTest `xml:"test,omitempty"`
+}
+
+// TNGResults TestNG results (root element of testng-results.xml) structure
+type TNGResults struct {
+	XMLName xml.Name    `xml:"testng-results"`
+	Total   string      `xml:"total,attr"`
+	Passed  string      `xml:"passed,attr"`
+	Failed  string      `xml:"failed,attr"`
+	Skipped string      `xml:"skipped,attr"`
+	Suite   []*TNGSuite `xml:"suite,omitempty"`
+}
+
+// TNGTestReport TestNG test report structure
+type TNGTestReport struct {
+}
+
+// Parse TestNG XML test result reports (testng-results.xml)
+func (tngtr *TNGTestReport) Parse(reportRootPath string) []TestSuite {
+	defer utils.TimeTrack(time.Now(), "Scan TestNG XML test reports")
+
+	return parseReportFiles(reportRootPath, func(path string) bool {
+		return hasExtension(path, ".xml")
+	}, readContent(parseTestNGFile))
+}
+
+func parseTestNGFile(xmlFilePath string, xfb []byte, ts []TestSuite) []TestSuite {
+	var tngResults TNGResults
+	err := xml.Unmarshal(xfb, &tngResults)
+	if err != nil {
+		// Most likely some other XML file (e.g. the JUnit compatible reports TestNG writes as well)
+		glog.Info("Not a TestNG result file ", xmlFilePath, ": ", err)
+		return ts
+	}
+
+	for _, tngSuite := range tngResults.Suite {
+		for _, tngTest := range tngSuite.Test {
+			ts = addTNGTestToTestResult(xmlFilePath, ts, *tngTest)
+		}
+	}
+
+	return ts
+}
+
+// Map the TestNG specific test (and within that classes and test methods) to the common (generalized)
+// TestSuite struct
+func addTNGTestToTestResult(xmlFile string, ts []TestSuite, tngTest TNGTest) []TestSuite {
+	var testcases []*TestCase
+	for _, tngClass := range tngTest.Class {
+		for _, tngMethod := range tngClass.TestMethod {
+			// Configuration methods (@BeforeMethod, @AfterClass, ...) are no tests
+			if tngMethod.IsConfig {
+				continue
+			}
+			testcase := &TestCase{ReportFileName: xmlFile, ClassName: tngClass.Name, MethodName: tngMethod.Name, Result: getTNGResult(tngMethod.Status), Timestamp: parseTimestamp(tngMethod.StartedAt)}
+			if durationMs, err := strconv.ParseFloat(tngMethod.DurationMs, 64); err == nil {
+				testcase.Duration = parseMilliseconds(durationMs)
+			}
+			if tngMethod.Exception != nil {
+				testcase.Message = strings.TrimSpace(tngMethod.Exception.Message)
+				testcase.StackTrace = strings.TrimSpace(tngMethod.Exception.FullStacktrace)
+			}
+			testcases = append(testcases, testcase)
+		}
+	}
+
+	if testcases == nil {
+		glog.Info("No test methods found in TestNG test ", tngTest.Name, " (", xmlFile, ")")
+		return ts
+	}
+
+	return append(ts, TestSuite{tngTest.Name, testcases})
+}
+
+// Map TestNG test method status (PASS, FAIL, SKIP) to our test result
+func getTNGResult(status string) int {
+	switch status {
+	case "PASS":
+		return SUCCESS
+	case "SKIP":
+		return SKIPPED
+	default:
+		return FAILURE
+	}
+}
@@ -0,0 +1,69 @@
+package testreport
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTestNGFile(t *testing.T) {
+	fp := "testng-results.xml"
+
+	x := []byte(`
+		<?xml version="1.0" encoding="UTF-8"?>
+		<testng-results skipped="1" failed="1" total="3" passed="1">
+			<reporter-output/>
+			<suite name="Default suite" duration-ms="33" started-at="2019-11-05T10:26:10Z" finished-at="2019-11-05T10:26:10Z">
+				<groups/>
+				<test name="Default test" duration-ms="33" started-at="2019-11-05T10:26:10Z" finished-at="2019-11-05T10:26:10Z">
+					<class name="com.sap.ctm.testing.MyTest">
+						<test-method status="PASS" signature="setUp()[pri:0, instance:com.sap.ctm.testing.MyTest@1]" name="setUp" is-config="true" duration-ms="1" started-at="2019-11-05T10:26:10Z" finished-at="2019-11-05T10:26:10Z"/>
+						<test-method status="PASS" signature="someTest()[pri:0, instance:com.sap.ctm.testing.MyTest@1]" name="someTest" duration-ms="3" started-at="2019-11-05T10:26:10Z" finished-at="2019-11-05T10:26:10Z"/>
+						<test-method status="FAIL" signature="otherTest()[pri:0, instance:com.sap.ctm.testing.MyTest@1]" name="otherTest" duration-ms="2" started-at="2019-11-05T10:26:10Z" finished-at="2019-11-05T10:26:10Z">
+							<exception class="java.lang.AssertionError">
+								<message><![CDATA[expected [true] but found [false]]]></message>
+								<full-stacktrace><![CDATA[java.lang.AssertionError: expected [true] but found [false]]]></full-stacktrace>
+							</exception>
+						</test-method>
+						<test-method status="SKIP" signature="skippedTest()[pri:0, instance:com.sap.ctm.testing.MyTest@1]" name="skippedTest" duration-ms="0" started-at="2019-11-05T10:26:10Z" finished-at="2019-11-05T10:26:10Z"/>
+					</class>
+				</test>
+			</suite>
+		</testng-results>
+	`)
+
+	var ts = []TestSuite{}
+	ts = parseTestNGFile(fp, x, ts)
+
+	if len(ts) != 1 {
+		t.Fatal("Should parse exactly one test suite")
+	}
+	if ts[0].Name != "Default test" {
+		t.Error("Invalid test suite name was parsed")
+	}
+	checkTestCases(t, ts[0].TestCase, []expectedTestCase{
+		{"com.sap.ctm.testing.MyTest", "someTest", SUCCESS},
+		{"com.sap.ctm.testing.MyTest", "otherTest", FAILURE},
+		{"com.sap.ctm.testing.MyTest", "skippedTest", SKIPPED},
+	})
+
+	if timestamp := ts[0].TestCase[0].Timestamp; !timestamp.Equal(time.Date(2019, 11, 5, 10, 26, 10, 0, time.UTC)) {
+		t.Error("Invalid start time was parsed: ", timestamp)
+	}
+}
+
+func TestParseTestNGFileIgnoresOtherXML(t *testing.T) {
+	fp := "TEST-com.sap.ctm.testing.MyTest.xml"
+
+	x := []byte(`
+		<testsuite name="com.sap.ctm.testing.MyTest" tests="1" errors="0" failures="0">
+			<testcase name="someTest" classname="com.sap.ctm.testing.MyTest"/>
+		</testsuite>
+	`)
+
+	var ts = []TestSuite{}
+	ts = parseTestNGFile(fp, x, ts)
+
+	if len(ts) != 0 {
+		t.Error("Should not parse a test suite from a non TestNG XML file")
+	}
+}
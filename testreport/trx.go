@@ -0,0 +1,166 @@
+package testreport
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SAP/quality-continuous-traceability-monitor/utils"
+	"github.com/golang/glog"
+)
+
+// TRXErrorInfo Visual Studio test result error info struct
+type TRXErrorInfo struct {
+	Message    string `xml:"Message"`
+	StackTrace string `xml:"StackTrace"`
+}
+
+// TRXOutput Visual Studio test result output struct
+type TRXOutput struct {
+	StdOut    string        `xml:"StdOut,omitempty"`
+	StdErr    string        `xml:"StdErr,omitempty"`
+	ErrorInfo *TRXErrorInfo `xml:"ErrorInfo,omitempty"`
+}
+
+// TRXUnitTestResult Visual Studio unit test result structure (the result of one test execution, refers to its UnitTest definition by TestID)
+type TRXUnitTestResult struct {
+	ExecutionID string     `xml:"executionId,attr"`
+	TestID      string     `xml:"testId,attr"`
+	TestName    string     `xml:"testName,attr"`
+	Duration    string     `xml:"duration,attr,omitempty"`
+	StartTime   string     `xml:"startTime,attr,omitempty"`
+	EndTime     string     `xml:"endTime,attr,omitempty"`
+	Outcome     string     `xml:"outcome,attr"`
+	Output      *TRXOutput `xml:"Output,omitempty"`
+}
+
+// TRXTestMethod Visual Studio test method structure
+type TRXTestMethod struct {
+	CodeBase  string `xml:"codeBase,attr,omitempty"`
+	ClassName string `xml:"className,attr"`
+	Name      string `xml:"name,attr"`
+}
+
+// TRXUnitTest Visual Studio unit test (definition) structure
+type TRXUnitTest struct {
+	ID         string         `xml:"id,attr"`
+	Name       string         `xml:"name,attr"`
+	Storage    string         `xml:"storage,attr,omitempty"`
+	TestMethod *TRXTestMethod `xml:"TestMethod,omitempty"`
+}
+
+// TRXTestRun Visual Studio test run (root element of a .trx file) structure
+type TRXTestRun struct {
+	XMLName         xml.Name             `xml:"TestRun"`
+	ID              string               `xml:"id,attr"`
+	Name            string               `xml:"name,attr"`
+	Results         []*TRXUnitTestResult `xml:"Results>UnitTestResult,omitempty"`
+	TestDefinitions []*TRXUnitTest       `xml:"TestDefinitions>UnitTest,omitempty"`
+}
+
+// TRXTestReport Visual Studio test report structure
+type TRXTestReport struct {
+}
+
+// Parse Visual Studio (dotnet test --logger trx, Azure DevOps) TRX test result reports
+func (trxtr *TRXTestReport) Parse(reportRootPath string) []TestSuite {
+	defer utils.TimeTrack(time.Now(), "Scan TRX test reports")
+
+	return parseReportFiles(reportRootPath, func(path string) bool {
+		return hasExtension(path, ".trx")
+	}, readContent(parseTRXFile))
+}
+
+func parseTRXFile(trxFilePath string, xfb []byte, ts []TestSuite) []TestSuite {
+	var trxTestRun TRXTestRun
+	err := xml.Unmarshal(xfb, &trxTestRun)
+	if err != nil {
+		glog.Error("Unable to parse TRX file ", trxFilePath, ": ", err)
+		return ts
+	}
+
+	if trxTestRun.Results == nil {
+		glog.Info("No test cases found in ", trxFilePath)
+		return ts
+	}
+
+	return addTRXTestRunToTestResult(trxFilePath, ts, trxTestRun)
+}
+
+// Map the TRX specific test results to the common (generalized) TestSuite struct.
+// The results only refer to the test (definition) which holds class and method name. One TestSuite per test class is created
+func addTRXTestRunToTestResult(trxFile string, ts []TestSuite, trxTestRun TRXTestRun) []TestSuite {
+	var definitions = make(map[string]*TRXUnitTest)
+	for _, unitTest := range trxTestRun.TestDefinitions {
+		definitions[unitTest.ID] = unitTest
+	}
+
+	var suites []TestSuite
+	var suiteIndex = make(map[string]int)
+	for _, result := range trxTestRun.Results {
+		unitTest, found := definitions[result.TestID]
+		if !found || unitTest.TestMethod == nil {
+			glog.Warning("No test definition found for test ", result.TestName, " (", result.TestID, ") in ", trxFile)
+			continue
+		}
+
+		className := getTRXClassName(unitTest.TestMethod.ClassName)
+		testcase := &TestCase{ReportFileName: trxFile, ClassName: className, MethodName: unitTest.TestMethod.Name, Result: getTRXResult(result.Outcome), Duration: getTRXDuration(result.Duration), Timestamp: parseTimestamp(result.StartTime)}
+		if result.Output != nil {
+			testcase.Stdout = truncateOutput(result.Output.StdOut)
+			testcase.Stderr = truncateOutput(result.Output.StdErr)
+			if result.Output.ErrorInfo != nil {
+				testcase.Message = strings.TrimSpace(result.Output.ErrorInfo.Message)
+				testcase.StackTrace = strings.TrimSpace(result.Output.ErrorInfo.StackTrace)
+			}
+		}
+
+		i, found := suiteIndex[className]
+		if !found {
+			i = len(suites)
+			suiteIndex[className] = i
+			suites = append(suites, TestSuite{Name: className})
+		}
+		suites[i].TestCase = append(suites[i].TestCase, testcase)
+	}
+
+	return append(ts, suites...)
+}
+
+// Older MSTest versions write the assembly qualified class name (e.g. "MyApp.Tests.MyTest, MyApp.Tests, Version=1.0.0.0").
+// We're only interested in the class name itself
+func getTRXClassName(className string) string {
+	if i := strings.Index(className, ","); i != -1 {
+		return strings.TrimSpace(className[:i])
+	}
+	return className
+}
+
+// Map TRX test outcome (Passed, Failed, NotExecuted, Inconclusive, Timeout, ...) to our test result
+func getTRXResult(outcome string) int {
+	switch outcome {
+	case "Passed":
+		return SUCCESS
+	case "NotExecuted", "Inconclusive", "Pending", "NotRunnable", "Disconnected":
+		return SKIPPED
+	case "Error", "Timeout", "Aborted":
+		return ERROR
+	default:
+		return FAILURE
+	}
+}
+
+// TRX durations are written as hh:mm:ss.fffffff (e.g. 00:00:01.2345678)
+func getTRXDuration(duration string) time.Duration {
+	parts := strings.Split(duration, ":")
+	if len(parts) != 3 {
+		return 0
+	}
+	hours, errH := strconv.Atoi(parts[0])
+	minutes, errM := strconv.Atoi(parts[1])
+	if errH != nil || errM != nil {
+		return 0
+	}
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + parseSeconds(parts[2])
+}
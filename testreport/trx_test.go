@@ -0,0 +1,104 @@
+package testreport
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTRXFile(t *testing.T) {
+	fp := "results.trx"
+
+	x := []byte(`<?xml version="1.0" encoding="utf-8"?>
+		<TestRun id="e3b0c442-98fc-1c14-9afb-f4c8996fb924" name="agent@build 2019-11-05 10:26:10" xmlns="http://microsoft.com/schemas/VisualStudio/TeamTest/2010">
+			<Results>
+				<UnitTestResult executionId="00000000-0000-0000-0000-000000000011" testId="00000000-0000-0000-0000-000000000001" testName="Add" duration="00:00:00.0120000" startTime="2019-11-05T11:26:10.1000000+01:00" outcome="Passed"/>
+				<UnitTestResult executionId="00000000-0000-0000-0000-000000000012" testId="00000000-0000-0000-0000-000000000002" testName="Divide" duration="00:00:00.0200000" outcome="Failed">
+					<Output>
+						<ErrorInfo>
+							<Message>Assert.AreEqual failed. Expected:&lt;2&gt;. Actual:&lt;3&gt;.</Message>
+							<StackTrace>at MyApp.Tests.CalculatorTests.Divide()</StackTrace>
+						</ErrorInfo>
+					</Output>
+				</UnitTestResult>
+				<UnitTestResult executionId="00000000-0000-0000-0000-000000000013" testId="00000000-0000-0000-0000-000000000003" testName="Subtract" outcome="NotExecuted"/>
+				<UnitTestResult executionId="00000000-0000-0000-0000-000000000014" testId="00000000-0000-0000-0000-000000000004" testName="Parse" outcome="Passed"/>
+			</Results>
+			<TestDefinitions>
+				<UnitTest name="Add" storage="/tmp/myapp.tests.dll" id="00000000-0000-0000-0000-000000000001">
+					<Execution id="00000000-0000-0000-0000-000000000011"/>
+					<TestMethod codeBase="/tmp/MyApp.Tests.dll" className="MyApp.Tests.CalculatorTests" name="Add"/>
+				</UnitTest>
+				<UnitTest name="Divide" storage="/tmp/myapp.tests.dll" id="00000000-0000-0000-0000-000000000002">
+					<Execution id="00000000-0000-0000-0000-000000000012"/>
+					<TestMethod codeBase="/tmp/MyApp.Tests.dll" className="MyApp.Tests.CalculatorTests" name="Divide"/>
+				</UnitTest>
+				<UnitTest name="Subtract" storage="/tmp/myapp.tests.dll" id="00000000-0000-0000-0000-000000000003">
+					<Execution id="00000000-0000-0000-0000-000000000013"/>
+					<TestMethod codeBase="/tmp/MyApp.Tests.dll" className="MyApp.Tests.CalculatorTests" name="Subtract"/>
+				</UnitTest>
+				<UnitTest name="Parse" storage="/tmp/myapp.tests.dll" id="00000000-0000-0000-0000-000000000004">
+					<Execution id="00000000-0000-0000-0000-000000000014"/>
+					<TestMethod codeBase="/tmp/MyApp.Tests.dll" className="MyApp.Tests.ParserTests, MyApp.Tests, Version=1.0.0.0, Culture=neutral, PublicKeyToken=null" name="Parse"/>
+				</UnitTest>
+			</TestDefinitions>
+		</TestRun>
+	`)
+
+	var ts = []TestSuite{}
+	ts = parseTRXFile(fp, x, ts)
+
+	if len(ts) != 2 {
+		t.Fatal("Should parse exactly two test suites (one per test class)")
+	}
+	checkTestCases(t, ts[0].TestCase, []expectedTestCase{
+		{"MyApp.Tests.CalculatorTests", "Add", SUCCESS},
+		{"MyApp.Tests.CalculatorTests", "Divide", FAILURE},
+		{"MyApp.Tests.CalculatorTests", "Subtract", SKIPPED},
+	})
+	checkTestCases(t, ts[1].TestCase, []expectedTestCase{
+		{"MyApp.Tests.ParserTests", "Parse", SUCCESS},
+	})
+
+	if timestamp := ts[0].TestCase[0].Timestamp; !timestamp.Equal(time.Date(2019, 11, 5, 10, 26, 10, 100000000, time.UTC)) {
+		t.Error("Invalid start time was parsed: ", timestamp)
+	}
+	if timestamp := ts[1].TestCase[0].Timestamp; !timestamp.IsZero() {
+		t.Error("Test without start time should have no timestamp, got: ", timestamp)
+	}
+}
+
+func TestParseTRXFileWithMissingDefinition(t *testing.T) {
+	fp := "results.trx"
+
+	x := []byte(`
+		<TestRun id="e3b0c442-98fc-1c14-9afb-f4c8996fb924" xmlns="http://microsoft.com/schemas/VisualStudio/TeamTest/2010">
+			<Results>
+				<UnitTestResult executionId="00000000-0000-0000-0000-000000000011" testId="00000000-0000-0000-0000-000000000001" testName="Add" outcome="Passed"/>
+			</Results>
+			<TestDefinitions/>
+		</TestRun>
+	`)
+
+	var ts = []TestSuite{}
+	ts = parseTRXFile(fp, x, ts)
+
+	if len(ts) != 0 {
+		t.Error("Should not create test cases for results without a test definition")
+	}
+}
+
+func TestGetTRXResult(t *testing.T) {
+	tests := map[string]int{
+		"Passed":      SUCCESS,
+		"Failed":      FAILURE,
+		"Error":       ERROR,
+		"Timeout":     ERROR,
+		"Aborted":     ERROR,
+		"NotExecuted": SKIPPED,
+	}
+	for outcome, expected := range tests {
+		if actual := getTRXResult(outcome); actual != expected {
+			t.Errorf("Outcome %s should be mapped to %d, got %d", outcome, expected, actual)
+		}
+	}
+}
@@ -3,10 +3,9 @@ package testreport
 import (
 	"encoding/xml"
 	"github.com/SAP/quality-continuous-traceability-monitor/utils"
-	"io/ioutil"
-	"os"
-	"path/filepath"
+	"io"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/golang/glog"
@@ -54,34 +53,40 @@ type XUSystemErr struct {
 
 // XUTestcase xunit test case structure
 type XUTestcase struct {
-	Classname    string       `xml:"classname,attr,omitempty"`
-	Group        string       `xml:"group,attr,omitempty"`
-	Name         string       `xml:"name,attr"`
-	Time         string       `xml:"time,attr,omitempty"`
-	File         string       `xml:"file,attr,omitempty"` // Python specific
-	Line         string       `xml:"line,attr,omitempty"` // Python specific
-	Error        *XUError     `xml:"error,omitempty"`
-	Skipped      *XUSkipped   `xml:"skipped,omitempty"`
-	Failure      *XUFailure   `xml:"failure,omitempty"`
-	ReRunFailure *XUFailure   `xml:"rerunFailure,omitempty"`
-	SystemOut    *XUSystemOut `xml:"system-out,omitempty"`
-	SystemErr    *XUSystemErr `xml:"system-err,omitempty"`
+	Classname    string        `xml:"classname,attr,omitempty"`
+	Group        string        `xml:"group,attr,omitempty"`
+	Name         string        `xml:"name,attr"`
+	Time         string        `xml:"time,attr,omitempty"`
+	File         string        `xml:"file,attr,omitempty"` // Python specific
+	Line         string        `xml:"line,attr,omitempty"` // Python specific
+	Error        *XUError      `xml:"error,omitempty"`
+	Skipped      *XUSkipped    `xml:"skipped,omitempty"`
+	Failure      *XUFailure    `xml:"failure,omitempty"`
+	ReRunFailure []*XUFailure  `xml:"rerunFailure,omitempty"` // Surefire: Failures of reruns of a failed test
+	ReRunError   []*XUError    `xml:"rerunError,omitempty"`   // Surefire: Errors of reruns of an erroneous test
+	FlakyFailure []*XUFailure  `xml:"flakyFailure,omitempty"` // Surefire: Failures of a test, which passed on rerun
+	FlakyError   []*XUError    `xml:"flakyError,omitempty"`   // Surefire: Errors of a test, which passed on rerun
+	Properties   *XUProperties `xml:"properties,omitempty"`   // e.g. JUnit 5 report entries or pytest record_property
+	SystemOut    *XUSystemOut  `xml:"system-out,omitempty"`
+	SystemErr    *XUSystemErr  `xml:"system-err,omitempty"`
 }
 
 // XUTestsuite xunit test suite structure
 type XUTestsuite struct {
-	Errors                 string        `xml:"errors,attr"`
-	Failures               string        `xml:"failures,attr"`
-	Name                   string        `xml:"name,attr"`
-	Skipped                string        `xml:"skipped,attr,omitempty"`
-	Group                  string        `xml:"group,attr,omitempty"` // omit empty to support Python
-	Skips                  string        `xml:"skips,attr,omitempty"` // Python specific
-	Tests                  string        `xml:"tests,attr"`
-	Time                   string        `xml:"time,attr,omitempty"`
-	Properties             *XUProperties `xml:"properties,omitempty"`
-	Testcase               []*XUTestcase `xml:"testcase,omitempty"`
-	XmlnsXsi               string        `xml:"xmlns xsi,attr,omitempty"`
-	XsiSpaceSchemaLocation string        `xml:"http://www.w3.org/2001/XMLSchema-instance schemaLocation,attr,omitempty"`
+	Errors                 string         `xml:"errors,attr"`
+	Failures               string         `xml:"failures,attr"`
+	Name                   string         `xml:"name,attr"`
+	Skipped                string         `xml:"skipped,attr,omitempty"`
+	Group                  string         `xml:"group,attr,omitempty"` // omit empty to support Python
+	Skips                  string         `xml:"skips,attr,omitempty"` // Python specific
+	Tests                  string         `xml:"tests,attr"`
+	Time                   string         `xml:"time,attr,omitempty"`
+	Timestamp              string         `xml:"timestamp,attr,omitempty"`
+	Properties             *XUProperties  `xml:"properties,omitempty"`
+	Testcase               []*XUTestcase  `xml:"testcase,omitempty"`
+	Testsuite              []*XUTestsuite `xml:"testsuite,omitempty"` // Nested test suites (e.g. Ant, karma, ctest or Bazel)
+	XmlnsXsi               string         `xml:"xmlns xsi,attr,omitempty"`
+	XsiSpaceSchemaLocation string         `xml:"http://www.w3.org/2001/XMLSchema-instance schemaLocation,attr,omitempty"`
 }
 
 // XUTestsuites xunit test suites structure
@@ -115,51 +120,94 @@ func (xuts *XUTestsuite) getSkipped() int {
 func (xutr *XUTestReport) Parse(reportRootPath string) []TestSuite {
 	defer utils.TimeTrack(time.Now(), "Scan xunit XML test reports")
 
-	var ts = []TestSuite{}
+	// We're only interssted in xml files
+	return parseReportFiles(reportRootPath, func(path string) bool {
+		return hasExtension(path, ".xml")
+	}, parseXunitFile)
+}
+
+// Parse a xunit file in a single pass, while it's read (the file isn't loaded into memory first). The file might either
+// have a single root testsuite or a root testsuites element. Each (top level) testsuite is decoded on its own, as soon
+// as the decoder reaches it. Test cases directly within the root testsuites element are collected in a test suite named
+// like the testsuites element
+func parseXunitFile(xmlFilePath string, content io.Reader, ts []TestSuite) []TestSuite {
+	decoder := xml.NewDecoder(content)
+	var inTestsuites bool
+	var rootTestsuite XUTestsuite
+	var numberOfTestSuites = len(ts)
 
-	filepath.Walk(reportRootPath, func(path string, fi os.FileInfo, err error) error {
-		if fi.IsDir() {
-			return nil
+tokens:
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			glog.Warning("Unable to parse xunit file ", xmlFilePath, ": ", err)
+			break
 		}
 
-		// We're only interssted in xml files
-		if filepath.Ext(path) == ".xml" {
-			glog.Info("Parsing ", path)
-			xmlFile, err := os.Open(path)
-			if err != nil {
-				glog.Error("Unable to open file: ", err)
+		element, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch {
+		case element.Name.Local == "testsuites" && !inTestsuites:
+			inTestsuites = true
+			for _, attr := range element.Attr {
+				if attr.Name.Local == "name" {
+					rootTestsuite.Name = attr.Value
+				}
 			}
-			defer xmlFile.Close()
-
-			xfb, _ := ioutil.ReadAll(xmlFile)
-			ts = parseXunitFile(path, xfb, ts)
+		case element.Name.Local == "testsuite":
+			var xuTestsuite XUTestsuite
+			if err := decoder.DecodeElement(&xuTestsuite, &element); err != nil {
+				glog.Warning("Unable to parse test suite in xunit file ", xmlFilePath, ": ", err)
+				break tokens
+			}
+			ts = addNestedXUTestSuitesToTestResult(xmlFilePath, ts, xuTestsuite, "")
+		case element.Name.Local == "testcase" && inTestsuites:
+			var xuTestcase XUTestcase
+			if err := decoder.DecodeElement(&xuTestcase, &element); err != nil {
+				glog.Warning("Unable to parse test case in xunit file ", xmlFilePath, ": ", err)
+				break tokens
+			}
+			rootTestsuite.Testcase = append(rootTestsuite.Testcase, &xuTestcase)
+		case !inTestsuites:
+			// Some other XML file
+			break tokens
+		default:
+			decoder.Skip()
 		}
+	}
 
-		return nil
-	})
+	if rootTestsuite.Testcase != nil {
+		ts = addXUTestSuiteToTestResult(xmlFilePath, ts, rootTestsuite)
+	}
 
+	if len(ts) == numberOfTestSuites {
+		glog.Warning("No test cases found in xunit file ", xmlFilePath, " (neither in its test suites nor in nested test suites)")
+	}
 	return ts
 }
 
-func parseXunitFile(xmlFilePath string, xfb []byte, ts []TestSuite) []TestSuite {
-	// try parsing with single root testsuite
-	var xuTestsuite XUTestsuite
-	xml.Unmarshal(xfb, &xuTestsuite)
-	if xuTestsuite.Testcase != nil {
-		return addXUTestSuiteToTestResult(xmlFilePath, ts, xuTestsuite)
+// Add the test suite (if it contains test cases) and all test suites nested within it (recursively). Nested test
+// suites are named by their path, e.g. parent.child
+func addNestedXUTestSuitesToTestResult(xmlFile string, ts []TestSuite, xuts XUTestsuite, parentName string) []TestSuite {
+	if parentName != "" {
+		xuts.Name = parentName + "." + xuts.Name
 	}
-
-	// try parsing with root testsuites
-	var xuTestsuites XUTestsuites
-	xml.Unmarshal(xfb, &xuTestsuites)
-	if xuTestsuites.Testsuite != nil {
-		for _, xuTestsuite := range xuTestsuites.Testsuite {
-			ts = addXUTestSuiteToTestResult(xmlFilePath, ts, *xuTestsuite)
+	if xuts.Testcase != nil {
+		ts = addXUTestSuiteToTestResult(xmlFile, ts, xuts)
+	}
+	for _, nested := range xuts.Testsuite {
+		nestedSuite := *nested
+		nestedSuite.Properties = nestedSuite.Properties.inherit(xuts.Properties)
+		if nestedSuite.Timestamp == "" {
+			nestedSuite.Timestamp = xuts.Timestamp
 		}
-		return ts
+		ts = addNestedXUTestSuitesToTestResult(xmlFile, ts, nestedSuite, xuts.Name)
 	}
-
-	glog.Info("No test cases found in ", xmlFilePath)
 	return ts
 }
 
@@ -173,13 +221,75 @@ func addXUTestSuiteToTestResult(xmlFile string, ts []TestSuite, xuts XUTestsuite
 		if xutestcase.Failure == nil && xutestcase.Error == nil && xutestcase.Skipped == nil {
 			result = SUCCESS
 		}
+		if xutestcase.Failure == nil && xutestcase.Error != nil { // Unexpected exception (e.g. infrastructure issue) instead of a failed assertion
+			result = ERROR
+		}
 		if xutestcase.Skipped != nil {
 			result = SKIPPED
 		}
-		testcase := &TestCase{xmlFile, xutestcase.Classname, xutestcase.Name, result}
+		if result == SUCCESS && xutestcase.isFlaky() {
+			result = FLAKY
+		}
+		testcase := &TestCase{ReportFileName: xmlFile, ClassName: xutestcase.Classname, MethodName: xutestcase.Name, Result: result, Duration: parseSeconds(xutestcase.Time)}
+		testcase.Properties = xutestcase.Properties.inherit(xuts.Properties).toMap()
+		testcase.Timestamp = parseTimestamp(xuts.Timestamp)
+		xutestcase.addDetails(testcase)
 		testcases = append(testcases, testcase)
 	}
 
 	// Create test suite and add it to test report
 	return append(ts, TestSuite{xuts.Name, testcases})
 }
+
+// Add failure (or error) message, stack trace and output of the xunit test case to the test case
+func (xutc *XUTestcase) addDetails(testcase *TestCase) {
+	if xutc.Failure != nil {
+		testcase.Message = xutc.Failure.Message
+		testcase.StackTrace = strings.TrimSpace(xutc.Failure.Text)
+	} else if xutc.Error != nil {
+		testcase.Message = xutc.Error.Message
+		testcase.StackTrace = strings.TrimSpace(xutc.Error.Text)
+	} else if xutc.Skipped != nil {
+		testcase.Message = xutc.Skipped.Message
+	} else if len(xutc.FlakyFailure) > 0 {
+		testcase.Message = xutc.FlakyFailure[0].Message
+		testcase.StackTrace = strings.TrimSpace(xutc.FlakyFailure[0].Text)
+	} else if len(xutc.FlakyError) > 0 {
+		testcase.Message = xutc.FlakyError[0].Message
+		testcase.StackTrace = strings.TrimSpace(xutc.FlakyError[0].Text)
+	}
+	if xutc.SystemOut != nil {
+		testcase.Stdout = truncateOutput(xutc.SystemOut.Text)
+	}
+	if xutc.SystemErr != nil {
+		testcase.Stderr = truncateOutput(xutc.SystemErr.Text)
+	}
+}
+
+// inherit returns the properties incl. the ones of the parent (test suite). Properties of the parent come first
+func (xup *XUProperties) inherit(parent *XUProperties) *XUProperties {
+	if parent == nil {
+		return xup
+	}
+	if xup == nil {
+		return parent
+	}
+	return &XUProperties{Property: append(append([]*XUProperty{}, parent.Property...), xup.Property...)}
+}
+
+// toMap returns the property names and (all) their values. The same property might be given multiple times
+func (xup *XUProperties) toMap() map[string][]string {
+	if xup == nil || len(xup.Property) == 0 {
+		return nil
+	}
+	var properties = make(map[string][]string)
+	for _, property := range xup.Property {
+		properties[property.Name] = append(properties[property.Name], property.Value)
+	}
+	return properties
+}
+
+// A test which passed, but failed before (e.g. on the first run with Surefire's rerunFailingTestsCount) is flaky
+func (xutc *XUTestcase) isFlaky() bool {
+	return len(xutc.FlakyFailure) > 0 || len(xutc.FlakyError) > 0 || len(xutc.ReRunFailure) > 0 || len(xutc.ReRunError) > 0
+}
@@ -1,7 +1,10 @@
 package testreport
 
 import (
+	"bytes"
+	"strings"
 	"testing"
+	"time"
 )
 
 func checkParsedTestSuite(t *testing.T, ts []TestSuite) {
@@ -35,8 +38,11 @@ func TestParseSingleTestsuite(t *testing.T) {
   `)
 
 	var ts = []TestSuite{}
-	ts = parseXunitFile(fp, x, ts)
+	ts = parseXunitFile(fp, bytes.NewReader(x), ts)
 	checkParsedTestSuite(t, ts)
+	if !ts[0].TestCase[0].Timestamp.Equal(time.Date(2017, 11, 9, 13, 47, 34, 0, time.UTC)) {
+		t.Error("Invalid timestamp was parsed: ", ts[0].TestCase[0].Timestamp)
+	}
 }
 
 func TestParseMultipleTestsuites(t *testing.T) {
@@ -54,7 +60,7 @@ func TestParseMultipleTestsuites(t *testing.T) {
   `)
 
 	var ts = []TestSuite{}
-	ts = parseXunitFile(fp, x, ts)
+	ts = parseXunitFile(fp, bytes.NewReader(x), ts)
 	checkParsedTestSuite(t, ts)
 }
 
@@ -68,9 +74,206 @@ func TestParseInvalid(t *testing.T) {
 	`)
 
 	var ts = []TestSuite{}
-	ts = parseXunitFile(fp, x, ts)
+	ts = parseXunitFile(fp, bytes.NewReader(x), ts)
 
 	if len(ts) != 0 {
 		t.Error("Should not parse a testsuite from invalid XML")
 	}
 }
+
+func TestParseNestedTestsuites(t *testing.T) {
+	fp := "test_path.xml"
+
+	x := []byte(`
+		<testsuites name="AllTests">
+			<testcase name="topLevelTest" classname="XUNIT.TopLevel"/>
+			<testsuite name="Outer">
+				<testcase name="outerTest" classname="XUNIT.Outer"/>
+				<testsuite name="Inner">
+					<testsuite name="Deeper">
+						<testcase name="deeperTest" classname="XUNIT.Deeper"><failure message="failed"/></testcase>
+					</testsuite>
+				</testsuite>
+			</testsuite>
+			<testsuite name="Other">
+				<testcase name="otherTest" classname="XUNIT.Other"/>
+			</testsuite>
+		</testsuites>
+	`)
+
+	var ts = []TestSuite{}
+	ts = parseXunitFile(fp, bytes.NewReader(x), ts)
+
+	if len(ts) != 4 {
+		t.Fatal("Should parse exactly four test suites, got ", len(ts))
+	}
+	if ts[0].Name != "Outer" || ts[1].Name != "Outer.Inner.Deeper" || ts[2].Name != "Other" || ts[3].Name != "AllTests" {
+		t.Error("Invalid test suite names were parsed: ", ts[0].Name, ", ", ts[1].Name, ", ", ts[2].Name, ", ", ts[3].Name)
+	}
+	checkTestCases(t, ts[1].TestCase, []expectedTestCase{{"XUNIT.Deeper", "deeperTest", FAILURE}})
+	checkTestCases(t, ts[3].TestCase, []expectedTestCase{{"XUNIT.TopLevel", "topLevelTest", SUCCESS}})
+}
+
+func TestParseNestedRootTestsuite(t *testing.T) {
+	fp := "test_path.xml"
+
+	x := []byte(`
+		<testsuite name="Root">
+			<testsuite name="Child">
+				<testcase name="This is simple test case" classname="XUNIT.Test"/>
+			</testsuite>
+		</testsuite>
+	`)
+
+	var ts = []TestSuite{}
+	ts = parseXunitFile(fp, bytes.NewReader(x), ts)
+	checkParsedTestSuite(t, ts)
+	if ts[0].Name != "Root.Child" {
+		t.Error("Invalid test suite name was parsed: ", ts[0].Name)
+	}
+}
+
+func TestParseProperties(t *testing.T) {
+	fp := "test_path.xml"
+
+	x := []byte(`
+		<testsuites>
+			<testsuite name="Outer">
+				<properties><property name="requirement" value="Jira:MYJIRAPROJECT-1"/></properties>
+				<testsuite name="Inner">
+					<properties><property name="component" value="login"/></properties>
+					<testcase name="someTest" classname="XUNIT.Test">
+						<properties>
+							<property name="requirement" value="Jira:MYJIRAPROJECT-2"/>
+							<property name="requirement" value="GitHub:myOrg/myRepo#5"/>
+						</properties>
+					</testcase>
+					<testcase name="otherTest" classname="XUNIT.Test"/>
+				</testsuite>
+			</testsuite>
+		</testsuites>
+	`)
+
+	var ts = []TestSuite{}
+	ts = parseXunitFile(fp, bytes.NewReader(x), ts)
+
+	if len(ts) != 1 || len(ts[0].TestCase) != 2 {
+		t.Fatal("Should parse exactly one test suite with two test cases")
+	}
+	properties := ts[0].TestCase[0].Properties
+	requirements := properties["requirement"]
+	if len(requirements) != 3 || requirements[0] != "Jira:MYJIRAPROJECT-1" || requirements[1] != "Jira:MYJIRAPROJECT-2" || requirements[2] != "GitHub:myOrg/myRepo#5" {
+		t.Error("Invalid requirement properties were parsed: ", requirements)
+	}
+	if component := properties["component"]; len(component) != 1 || component[0] != "login" {
+		t.Error("Invalid component property was parsed: ", component)
+	}
+	if requirements := ts[0].TestCase[1].Properties["requirement"]; len(requirements) != 1 || requirements[0] != "Jira:MYJIRAPROJECT-1" {
+		t.Error("Test suite properties should be inherited: ", requirements)
+	}
+}
+
+func TestParseOtherXML(t *testing.T) {
+	fp := "pom.xml"
+
+	x := []byte(`<?xml version="1.0"?>
+		<project>
+			<testsuite name="NotATestsuite"><testcase name="someTest" classname="com.sap.ctm.testing.MyTest"/></testsuite>
+		</project>
+	`)
+
+	var ts = []TestSuite{}
+	ts = parseXunitFile(fp, bytes.NewReader(x), ts)
+
+	if len(ts) != 0 {
+		t.Error("Should not parse a testsuite from other XML files")
+	}
+}
+
+func TestParseFailureDetails(t *testing.T) {
+	fp := "test_path.xml"
+
+	x := []byte(`
+		<testsuite name="XUNIT.Test" tests="3" errors="1" failures="1" time="1.5">
+			<testcase name="failingTest" time="1.25" classname="XUNIT.Test">
+				<failure message="expected: &lt;1&gt; but was: &lt;2&gt;" type="org.opentest4j.AssertionFailedError"><![CDATA[org.opentest4j.AssertionFailedError: expected: <1> but was: <2>
+	at XUNIT.Test.failingTest(Test.java:12)]]></failure>
+				<system-out><![CDATA[some output]]></system-out>
+				<system-err><![CDATA[some error output]]></system-err>
+			</testcase>
+			<testcase name="erroneousTest" time="0.25" classname="XUNIT.Test">
+				<error message="NullPointerException" type="java.lang.NullPointerException">java.lang.NullPointerException</error>
+			</testcase>
+			<testcase name="passingTest" classname="XUNIT.Test"/>
+		</testsuite>
+	`)
+
+	var ts = []TestSuite{}
+	ts = parseXunitFile(fp, bytes.NewReader(x), ts)
+
+	if len(ts) != 1 || len(ts[0].TestCase) != 3 {
+		t.Fatal("Should parse exactly one test suite with three test cases")
+	}
+
+	failing := ts[0].TestCase[0]
+	if failing.Message != "expected: <1> but was: <2>" {
+		t.Error("Invalid failure message was parsed: ", failing.Message)
+	}
+	if !strings.HasSuffix(failing.StackTrace, "at XUNIT.Test.failingTest(Test.java:12)") {
+		t.Error("Invalid stack trace was parsed: ", failing.StackTrace)
+	}
+	if failing.Duration != 1250*time.Millisecond {
+		t.Error("Invalid duration was parsed: ", failing.Duration)
+	}
+	if failing.Stdout != "some output" || failing.Stderr != "some error output" {
+		t.Error("Invalid output was parsed: ", failing.Stdout, " / ", failing.Stderr)
+	}
+
+	erroneous := ts[0].TestCase[1]
+	if failing.Result != FAILURE || erroneous.Result != ERROR {
+		t.Error("Failures and errors should be distinguished")
+	}
+	if erroneous.Message != "NullPointerException" || erroneous.StackTrace != "java.lang.NullPointerException" {
+		t.Error("Invalid error details were parsed: ", erroneous.Message, " / ", erroneous.StackTrace)
+	}
+
+	passing := ts[0].TestCase[2]
+	if passing.Message != "" || passing.StackTrace != "" || passing.Duration != 0 {
+		t.Error("Passing test case without time should have no details")
+	}
+}
+
+func TestParseSurefireRerunResults(t *testing.T) {
+	fp := "TEST-XUNIT.Test.xml"
+
+	x := []byte(`
+		<testsuite name="XUNIT.Test" tests="3" errors="0" failures="1" flakes="2">
+			<testcase name="flakyTest" classname="XUNIT.Test" time="0.5">
+				<flakyFailure message="expected: &lt;true&gt; but was: &lt;false&gt;" type="org.opentest4j.AssertionFailedError">stack trace</flakyFailure>
+			</testcase>
+			<testcase name="flakyErrorTest" classname="XUNIT.Test" time="0.5">
+				<flakyError message="Connection refused" type="java.net.ConnectException">stack trace</flakyError>
+				<flakyError message="Connection refused" type="java.net.ConnectException">stack trace</flakyError>
+			</testcase>
+			<testcase name="alwaysFailingTest" classname="XUNIT.Test" time="0.5">
+				<failure message="expected: &lt;1&gt; but was: &lt;2&gt;" type="org.opentest4j.AssertionFailedError">stack trace</failure>
+				<rerunFailure message="expected: &lt;1&gt; but was: &lt;2&gt;" type="org.opentest4j.AssertionFailedError">stack trace</rerunFailure>
+			</testcase>
+		</testsuite>
+	`)
+
+	var ts = []TestSuite{}
+	ts = parseXunitFile(fp, bytes.NewReader(x), ts)
+
+	if len(ts) != 1 {
+		t.Fatal("Should parse exactly one test suite")
+	}
+	checkTestCases(t, ts[0].TestCase, []expectedTestCase{
+		{"XUNIT.Test", "flakyTest", FLAKY},
+		{"XUNIT.Test", "flakyErrorTest", FLAKY},
+		{"XUNIT.Test", "alwaysFailingTest", FAILURE},
+	})
+	if ts[0].TestCase[0].Message != "expected: <true> but was: <false>" {
+		t.Error("Flaky failure message should be kept: ", ts[0].TestCase[0].Message)
+	}
+}
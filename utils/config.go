@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"os"
+	"regexp"
 	"strings"
 
 	"github.com/golang/glog"
@@ -11,6 +12,26 @@ import (
 
 const envKeyGithubAccesstoken = "GITHUB_TOKEN"
 
+// Policies to combine the test results of a requirement in multiple environments (testResults.environmentPolicy)
+const (
+	// EnvironmentPolicyAll requirement needs to pass in all environments (default)
+	EnvironmentPolicyAll = "all"
+	// EnvironmentPolicyAny requirement needs to pass in one environment only
+	EnvironmentPolicyAny = "any"
+)
+
+// Strategies to merge the runs of the same test in multiple test reports, e.g. of retried CI jobs (testResults.mergeStrategy)
+const (
+	// MergeStrategyLatest the latest run decides on the test result (default)
+	MergeStrategyLatest = "latest"
+	// MergeStrategyAnyPass test passed, if it passed in any run
+	MergeStrategyAnyPass = "anyPass"
+	// MergeStrategyAllPass test passed, if it passed in all runs
+	MergeStrategyAllPass = "allPass"
+	// MergeStrategyNone runs aren't merged, every run is reported on its own
+	MergeStrategyNone = "none"
+)
+
 // Git coordinates
 type Git struct {
 	Organization string
@@ -34,6 +55,14 @@ type Sourcecode struct {
 	CustomURLTemplate string
 }
 
+// TestNameExtractor extracts backlog items from test (class and method) names. Pattern is a regular expression,
+// BacklogItem a template for the backlog item (e.g. Jira:$1-$2) expanded for each match of the pattern. Without
+// template, the first submatch (or the whole match) of the pattern is taken as backlog item
+type TestNameExtractor struct {
+	Pattern     string `json:"pattern"`
+	BacklogItem string `json:"backlogItem,omitempty"`
+}
+
 // Config struct representation of your JSON config file
 type Config struct {
 	Github struct {
@@ -51,12 +80,20 @@ type Config struct {
 	} `json:"jira,omitempty"`
 	Sourcecode []Sourcecode
 	Mapping    struct {
-		Local string
+		Local                 string
+		RequirementProperties []string            `json:"requirementProperties,omitempty"` // Test case properties in test reports (e.g. xunit property elements) holding backlog items
+		TestNameExtractors    []TestNameExtractor `json:"testNameExtractors,omitempty"`    // Extract backlog items from the test names in test reports
 	}
 	TestReport []struct {
 		Type  string
 		Local string
+		Label string `json:"label,omitempty"` // Environment the tests were run in (e.g. linux-jdk17)
 	}
+	TestResults struct {
+		FlakyAsPassed     bool   `json:"flakyAsPassed,omitempty"`     // Count requirements with flaky (but finally passed) tests as successful
+		EnvironmentPolicy string `json:"environmentPolicy,omitempty"` // Combine the results of multiple environments (EnvironmentPolicyAll or EnvironmentPolicyAny)
+		MergeStrategy     string `json:"mergeStrategy,omitempty"`     // Merge the runs of the same test in multiple test reports (e.g. MergeStrategyLatest)
+	} `json:"testResults,omitempty"`
 	TraceabilityRepo struct {
 		Git Git
 	}
@@ -170,11 +207,39 @@ func (cfg *Config) ReadConfig(configFilePath *string) {
 		}
 	}
 
-	// Check if test reports dirs exists (and that they contain files)
+	// Check the policy to combine the test results of multiple environments
+	switch cfg.TestResults.EnvironmentPolicy {
+	case "":
+		cfg.TestResults.EnvironmentPolicy = EnvironmentPolicyAll
+	case EnvironmentPolicyAll, EnvironmentPolicyAny:
+	default:
+		glog.Fatal("Unknown environment policy (Given policy was: ", cfg.TestResults.EnvironmentPolicy, "). Use '", EnvironmentPolicyAll, "' or '", EnvironmentPolicyAny, "'")
+	}
+
+	// Check the strategy to merge the runs of the same test
+	switch cfg.TestResults.MergeStrategy {
+	case "":
+		cfg.TestResults.MergeStrategy = MergeStrategyLatest
+	case MergeStrategyLatest, MergeStrategyAnyPass, MergeStrategyAllPass, MergeStrategyNone:
+	default:
+		glog.Fatal("Unknown merge strategy (Given strategy was: ", cfg.TestResults.MergeStrategy, "). Use '", MergeStrategyLatest, "', '",
+			MergeStrategyAnyPass, "', '", MergeStrategyAllPass, "' or '", MergeStrategyNone, "'")
+	}
+
+	// Check if the test name extractors are valid regular expressions
+	for _, extractor := range cfg.Mapping.TestNameExtractors {
+		if _, err := regexp.Compile(extractor.Pattern); err != nil {
+			glog.Fatal("Invalid test name extractor pattern (Given pattern was: ", extractor.Pattern, "): ", err)
+		}
+	}
+
+	// Check if test reports dirs exists (and that they contain files). A test report might also be an archive (.zip or .tar.gz)
 	for _, trPath := range cfg.TestReport {
 
 		if Exists(trPath.Local) == false {
 			glog.Fatal("Given test report directory does not exist (Given test report directory was: ", trPath.Local)
+		} else if fi, err := os.Stat(trPath.Local); err == nil && !fi.IsDir() {
+			continue
 		} else {
 			files, err := ioutil.ReadDir(trPath.Local)
 			if err != nil {